@@ -0,0 +1,231 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/didyoumean"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// accountPrivileges lists the global privileges which can be granted on the
+// account itself. IMPORTED PRIVILEGES is the exception: it is granted on the
+// shared SNOWFLAKE database, which is how access to account usage is given.
+var accountPrivileges = []string{
+	"APPLY MASKING POLICY",
+	"APPLY ROW ACCESS POLICY",
+	"APPLY TAG",
+	"ATTACH POLICY",
+	"AUDIT",
+	"CREATE ACCOUNT",
+	"CREATE DATA EXCHANGE LISTING",
+	"CREATE DATABASE",
+	"CREATE INTEGRATION",
+	"CREATE NETWORK POLICY",
+	"CREATE ROLE",
+	"CREATE SHARE",
+	"CREATE USER",
+	"CREATE WAREHOUSE",
+	"EXECUTE ALERT",
+	"EXECUTE MANAGED TASK",
+	"EXECUTE TASK",
+	"IMPORT SHARE",
+	"IMPORTED PRIVILEGES",
+	"MANAGE GRANTS",
+	"MONITOR EXECUTION",
+	"MONITOR USAGE",
+	"OVERRIDE SHARE RESTRICTIONS",
+}
+
+// accountObjectPrivileges lists, for each type of object which lives directly
+// in the account, the privileges which can be granted on it.
+var accountObjectPrivileges = map[string][]string{
+	"DATABASE":         {"CREATE SCHEMA", "IMPORTED PRIVILEGES", "MODIFY", "MONITOR", "REFERENCE_USAGE", "USAGE"},
+	"INTEGRATION":      {"USAGE"},
+	"RESOURCE MONITOR": {"MODIFY", "MONITOR"},
+	"WAREHOUSE":        {"MODIFY", "MONITOR", "OPERATE", "USAGE"},
+}
+
+// schemaPrivileges lists the privileges which can be granted on a schema.
+var schemaPrivileges = []string{
+	"ADD SEARCH OPTIMIZATION",
+	"CREATE DYNAMIC TABLE",
+	"CREATE EXTERNAL TABLE",
+	"CREATE FILE FORMAT",
+	"CREATE FUNCTION",
+	"CREATE MASKING POLICY",
+	"CREATE MATERIALIZED VIEW",
+	"CREATE PIPE",
+	"CREATE PROCEDURE",
+	"CREATE ROW ACCESS POLICY",
+	"CREATE SEQUENCE",
+	"CREATE STAGE",
+	"CREATE STREAM",
+	"CREATE TABLE",
+	"CREATE TAG",
+	"CREATE TASK",
+	"CREATE VIEW",
+	"MODIFY",
+	"MONITOR",
+	"USAGE",
+}
+
+// schemaObjectPrivileges lists, for each type of object which lives in a
+// schema, the privileges which can be granted on it.
+var schemaObjectPrivileges = map[string][]string{
+	"DYNAMIC TABLE":     {"MONITOR", "OPERATE", "SELECT"},
+	"EXTERNAL TABLE":    {"REFERENCES", "SELECT"},
+	"FILE FORMAT":       {"USAGE"},
+	"FUNCTION":          {"USAGE"},
+	"MATERIALIZED VIEW": {"REFERENCES", "SELECT"},
+	"PIPE":              {"MONITOR", "OPERATE"},
+	"PROCEDURE":         {"USAGE"},
+	"SEQUENCE":          {"USAGE"},
+	"STAGE":             {"READ", "USAGE", "WRITE"},
+	"STREAM":            {"SELECT"},
+	"TABLE":             {"DELETE", "EVOLVE SCHEMA", "INSERT", "REFERENCES", "SELECT", "TRUNCATE", "UPDATE"},
+	"TASK":              {"MONITOR", "OPERATE"},
+	"VIEW":              {"REFERENCES", "SELECT"},
+}
+
+// sharePrivileges lists, for each type of object which can be added to a
+// share, the privileges which can be granted to the share on it.
+var sharePrivileges = map[string][]string{
+	"DATABASE":          {"REFERENCE_USAGE", "USAGE"},
+	"EXTERNAL TABLE":    {"SELECT"},
+	"FUNCTION":          {"USAGE"},
+	"MATERIALIZED VIEW": {"SELECT"},
+	"SCHEMA":            {"USAGE"},
+	"TABLE":             {"SELECT"},
+	"VIEW":              {"SELECT"},
+}
+
+// privilegesNotInAll lists privileges from the catalogs which GRANT ALL does
+// not always include, so they are not required when recognising a grant of
+// ALL. Some are never part of ALL, the others depend on the edition of the
+// account or on the role running the grant, such as CREATE ACCOUNT which only
+// the organization's account administrators hold.
+var privilegesNotInAll = []string{
+	"ADD SEARCH OPTIMIZATION",
+	"APPLY MASKING POLICY",
+	"APPLY ROW ACCESS POLICY",
+	"APPLY TAG",
+	"CREATE ACCOUNT",
+	"CREATE DATA EXCHANGE LISTING",
+	"CREATE MASKING POLICY",
+	"CREATE ROW ACCESS POLICY",
+	"CREATE TAG",
+	"EVOLVE SCHEMA",
+	"IMPORTED PRIVILEGES",
+	"OVERRIDE SHARE RESTRICTIONS",
+	"REFERENCE_USAGE",
+}
+
+// objectTypes returns the object types of a privilege catalog in lexical
+// order, for use in validation.
+func objectTypes(catalog map[string][]string) []string {
+	types := make([]string, 0, len(catalog))
+	for t := range catalog {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// isGrantedOn reports whether the granted_on column of SHOW GRANTS refers to
+// objectType. Snowflake reports multi-word types with underscores, such as
+// FILE_FORMAT, where the SQL syntax uses spaces.
+func isGrantedOn(grantedOn, objectType string) bool {
+	return strings.Replace(grantedOn, "_", " ", -1) == strings.Replace(objectType, "_", " ", -1)
+}
+
+// validatePrivileges checks every privilege in the set against the privileges
+// which can be granted on objectType. ALL is always accepted.
+func validatePrivileges(objectType string, valid []string, privileges *schema.Set) error {
+	if privileges.Contains("ALL") && privileges.Len() > 1 {
+		log.Printf("[WARN] privileges on %s contain ALL together with other privileges, only ALL will be granted", objectType)
+	}
+
+	for _, v := range privileges.List() {
+		privilege := v.(string)
+		if privilege == "ALL" || stringInSlice(privilege, valid) {
+			continue
+		}
+
+		if suggestion := didyoumean.NameSuggestion(privilege, valid); suggestion != "" {
+			return fmt.Errorf("%q is not a privilege which can be granted on %s. Did you mean %q?", privilege, objectType, suggestion)
+		}
+		return fmt.Errorf("%q is not a privilege which can be granted on %s. Valid privileges are: %v", privilege, objectType, valid)
+	}
+	return nil
+}
+
+// customizeObjectGrantDiff validates the privileges of a grant resource which
+// has an object_type attribute against the given catalog.
+func customizeObjectGrantDiff(catalog map[string][]string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown("object_type") || !d.NewValueKnown("privileges") {
+			return nil
+		}
+
+		objectType := strings.ToUpper(d.Get("object_type").(string))
+		valid, ok := catalog[objectType]
+		if !ok {
+			// object_type is checked by its own ValidateFunc.
+			return nil
+		}
+
+		return validatePrivileges(objectType, valid, d.Get("privileges").(*schema.Set))
+	}
+}
+
+// privilegesForState returns the set of privileges to store in state, given
+// the privileges granted on an object. Snowflake reports a grant of ALL as the
+// individual privileges it expands to, so when the configuration asks for ALL
+// and every privilege which ALL grants on the object type is present, ALL is
+// kept in state instead of the expanded list, together with any privilege
+// configured alongside ALL.
+func privilegesForState(granted []interface{}, valid []string, configured *schema.Set) *schema.Set {
+	grantedSet := schema.NewSet(schema.HashString, granted)
+	if !configured.Contains("ALL") || len(valid) == 0 {
+		return grantedSet
+	}
+
+	for _, privilege := range valid {
+		if !grantedSet.Contains(privilege) && !stringInSlice(privilege, privilegesNotInAll) {
+			return grantedSet
+		}
+	}
+
+	state := schema.NewSet(schema.HashString, []interface{}{"ALL"})
+	for _, privilege := range configured.Intersection(grantedSet).List() {
+		state.Add(privilege)
+	}
+	return state
+}
+
+// managedPrivilege reports whether a privilege read back from Snowflake
+// belongs in state. OWNERSHIP is never managed by the grant resources, and once
+// the privileges are known only those, or those which ALL expands to, are
+// kept, so that grants made outside of terraform are neither reported as a
+// diff nor revoked. On import nothing is known yet and every privilege is kept.
+func managedPrivilege(privilege string, valid []string, managed *schema.Set) bool {
+	if privilege == "OWNERSHIP" {
+		return false
+	}
+	if managed.Len() == 0 || managed.Contains(privilege) {
+		return true
+	}
+	return managed.Contains("ALL") && stringInSlice(privilege, valid)
+}
+
+func stringInSlice(s string, slice []string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
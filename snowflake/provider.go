@@ -70,10 +70,26 @@ func Provider() terraform.ResourceProvider {
 			"snowflake_user":                 resourceUser(),
 			"snowflake_role":                 resourceRole(),
 			"snowflake_role_grant":           resourceRoleGrant(),
+			"snowflake_role_hierarchy_grant": resourceRoleHierarchyGrant(),
+			"snowflake_role_grants":          resourceRoleGrants(),
+			"snowflake_account_grant":        resourceAccountGrant(),
 			"snowflake_account_object_grant": resourceAccountObjectGrant(),
 			"snowflake_schema":               resourceSchema(),
 			"snowflake_schema_grant":         resourceSchemaGrant(),
 			"snowflake_schema_object_grant":  resourceSchemaObjectGrant(),
+			"snowflake_ownership_grant":      resourceOwnershipGrant(),
+			"snowflake_share":                resourceShare(),
+			"snowflake_share_accounts":       resourceShareAccounts(),
+			"snowflake_share_grant":          resourceShareGrant(),
+			"snowflake_table":                resourceTable(),
+			"snowflake_table_constraint":     resourceTableConstraint(),
+			"snowflake_view":                 resourceView(),
+			"snowflake_materialized_view":    resourceMaterializedView(),
+			"snowflake_dynamic_table":        resourceDynamicTable(),
+			"snowflake_stage":                resourceStage(),
+			"snowflake_file_format":          resourceFileFormat(),
+			"snowflake_pipe":                 resourcePipe(),
+			"snowflake_stream":               resourceStream(),
 		},
 
 		ConfigureFunc: providerConfigure,
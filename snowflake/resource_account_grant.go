@@ -0,0 +1,188 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// importedPrivilegesTarget is where IMPORTED PRIVILEGES is granted, since
+// unlike the other global privileges it is not granted on the account.
+const importedPrivilegesTarget = "DATABASE \"SNOWFLAKE\""
+
+func resourceAccountGrant() *schema.Resource {
+	return &schema.Resource{
+		Create:        createAccountGrant,
+		Update:        updateAccountGrant,
+		Read:          readAccountGrant,
+		Delete:        deleteAccountGrant,
+		CustomizeDiff: customizeAccountGrantDiff,
+
+		Schema: map[string]*schema.Schema{
+			"privileges": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"role": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"grant_option": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func createAccountGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	role := d.Get("role").(string)
+	grantee := fmt.Sprintf("ROLE \"%s\"", role)
+
+	empty := schema.NewSet(schema.HashString, nil)
+	accountPrivileges, importedPrivileges := splitAccountPrivileges(d.Get("privileges").(*schema.Set))
+	grantOption := d.Get("grant_option").(bool)
+
+	statements := privilegeChangeStatements(empty, accountPrivileges, grantOption, grantOption, "ACCOUNT", grantee)
+	statements = append(statements, privilegeChangeStatements(empty, importedPrivileges, grantOption, grantOption, importedPrivilegesTarget, grantee)...)
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(role)
+
+	return readAccountGrant(d, meta)
+}
+
+func readAccountGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	role := d.Id()
+
+	stmtSQL := fmt.Sprintf("SHOW GRANTS TO ROLE \"%s\"", role)
+
+	log.Println("Executing statement:", stmtSQL)
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var (
+		createdOn         string
+		privilege         string
+		grantedOn         string
+		name              string
+		grantedTo         string
+		granteeName       string
+		grantOption       bool
+		grantedBy         string
+		privileges        []interface{}
+		objectGrantOption bool
+	)
+
+	managed := d.Get("privileges").(*schema.Set)
+
+	for rows.Next() {
+		if err := rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption, &grantedBy); err != nil {
+			return err
+		}
+
+		if !managedPrivilege(privilege, accountPrivileges, managed) {
+			continue
+		}
+		if grantedOn == "ACCOUNT" || (grantedOn == "DATABASE" && name == "SNOWFLAKE" && privilege == "IMPORTED PRIVILEGES") {
+			privileges = append(privileges, privilege)
+			objectGrantOption = grantOption
+		}
+	}
+
+	if len(privileges) > 0 {
+		d.Set("role", role)
+		d.Set("privileges", privilegesForState(privileges, accountPrivileges, managed))
+		d.Set("grant_option", objectGrantOption)
+		return nil
+	}
+
+	return fmt.Errorf("The grant of role %s on ACCOUNT does not exist.", role)
+}
+
+func updateAccountGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	grantee := fmt.Sprintf("ROLE \"%s\"", d.Id())
+
+	o, n := d.GetChange("privileges")
+	og, ng := d.GetChange("grant_option")
+	oldAccountPrivileges, oldImportedPrivileges := splitAccountPrivileges(o.(*schema.Set))
+	newAccountPrivileges, newImportedPrivileges := splitAccountPrivileges(n.(*schema.Set))
+
+	statements := privilegeChangeStatements(oldAccountPrivileges, newAccountPrivileges, og.(bool), ng.(bool), "ACCOUNT", grantee)
+	statements = append(statements, privilegeChangeStatements(oldImportedPrivileges, newImportedPrivileges, og.(bool), ng.(bool), importedPrivilegesTarget, grantee)...)
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readAccountGrant(d, meta)
+}
+
+func deleteAccountGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	grantee := fmt.Sprintf("ROLE \"%s\"", d.Id())
+
+	empty := schema.NewSet(schema.HashString, nil)
+	accountPrivileges, importedPrivileges := splitAccountPrivileges(d.Get("privileges").(*schema.Set))
+
+	statements := privilegeChangeStatements(accountPrivileges, empty, false, false, "ACCOUNT", grantee)
+	statements = append(statements, privilegeChangeStatements(importedPrivileges, empty, false, false, importedPrivilegesTarget, grantee)...)
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func customizeAccountGrantDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("privileges") {
+		return nil
+	}
+
+	return validatePrivileges("ACCOUNT", accountPrivileges, d.Get("privileges").(*schema.Set))
+}
+
+// splitAccountPrivileges separates IMPORTED PRIVILEGES, which is granted on
+// the SNOWFLAKE database, from the privileges granted on the account.
+func splitAccountPrivileges(privileges *schema.Set) (account, imported *schema.Set) {
+	account = schema.NewSet(schema.HashString, nil)
+	imported = schema.NewSet(schema.HashString, nil)
+
+	for _, v := range privileges.List() {
+		if v.(string) == "IMPORTED PRIVILEGES" {
+			imported.Add(v)
+		} else {
+			account.Add(v)
+		}
+	}
+
+	return account, imported
+}
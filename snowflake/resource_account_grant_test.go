@@ -0,0 +1,28 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAccountGrantSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeAccountGrantConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_account_grant.foo", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("snowflake_account_grant.foo", "grant_option", "false"),
+					resource.TestCheckResourceAttr("snowflake_account_grant.foo", "role", "test_role"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeAccountGrantConfig = `resource "snowflake_account_grant" "foo" {
+	privileges = ["CREATE DATABASE", "MONITOR USAGE"]
+	role = "test_role"
+}`
@@ -6,20 +6,24 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourceAccountObjectGrant() *schema.Resource {
 	return &schema.Resource{
-		Create: createAccountObjectGrant,
-		Update: nil,
-		Read:   readAccountObjectGrant,
-		Delete: deleteAccountObjectGrant,
+		Create:        createAccountObjectGrant,
+		Update:        updateAccountObjectGrant,
+		Read:          readAccountObjectGrant,
+		Delete:        deleteAccountObjectGrant,
+		CustomizeDiff: customizeObjectGrantDiff(accountObjectPrivileges),
 
 		Schema: map[string]*schema.Schema{
 			"object_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(objectTypes(accountObjectPrivileges), true),
+				DiffSuppressFunc: suppressCaseDiff,
 			},
 
 			"object_name": &schema.Schema{
@@ -31,7 +35,6 @@ func resourceAccountObjectGrant() *schema.Resource {
 			"privileges": &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
-				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -45,16 +48,22 @@ func resourceAccountObjectGrant() *schema.Resource {
 			"grant_option": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
 				Default:  false,
 			},
+
+			"revoke_all_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revoke all privileges on the object from the role on destroy, instead of only the managed ones",
+			},
 		},
 	}
 }
 
 func createAccountObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	objectType := d.Get("object_type").(string)
+	objectType := strings.ToUpper(d.Get("object_type").(string))
 	objectName := d.Get("object_name").(string)
 	role := d.Get("role").(string)
 
@@ -109,12 +118,15 @@ func readAccountObjectGrant(d *schema.ResourceData, meta interface{}) error {
 		objectGrantOption bool
 	)
 
+	managed := d.Get("privileges").(*schema.Set)
+	valid := accountObjectPrivileges[objectType]
+
 	for rows.Next() {
 		if err := rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption, &grantedBy); err != nil {
 			return err
 		}
 
-		if grantedTo == "ROLE" && granteeName == role {
+		if grantedTo == "ROLE" && granteeName == role && managedPrivilege(privilege, valid, managed) {
 			privileges = append(privileges, privilege)
 			objectGrantOption = grantOption
 		}
@@ -124,7 +136,7 @@ func readAccountObjectGrant(d *schema.ResourceData, meta interface{}) error {
 		d.Set("objectType", objectType)
 		d.Set("objectName", objectName)
 		d.Set("role", role)
-		d.Set("privileges", schema.NewSet(schema.HashString, privileges))
+		d.Set("privileges", privilegesForState(privileges, valid, managed))
 		d.Set("grant_option", objectGrantOption)
 		return nil
 	}
@@ -132,14 +144,27 @@ func readAccountObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	return fmt.Errorf("The grant of role %s on %s %s does not exist.", role, objectType, objectName)
 }
 
+func updateAccountObjectGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, role := getParamsFromGrantID(d.Id())
+
+	on := fmt.Sprintf("%s \"%s\"", objectType, objectName)
+
+	for _, stmtSQL := range grantUpdateStatements(d, on, role) {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readAccountObjectGrant(d, meta)
+}
+
 func deleteAccountObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
 	objectType, objectName, role := getParamsFromGrantID(d.Id())
 
-	stmtSQL := fmt.Sprintf("REVOKE ALL PRIVILEGES ON %s \"%s\" FROM ROLE \"%s\"",
-		objectType,
-		objectName,
-		role)
+	stmtSQL := revokeStatement(d, fmt.Sprintf("%s \"%s\"", objectType, objectName), role)
 
 	log.Println("Executing statement:", stmtSQL)
 	_, err := db.Exec(stmtSQL)
@@ -155,5 +180,5 @@ func generateGrantID(objectType, objectName, role string) string {
 
 func getParamsFromGrantID(id string) (objectType, objectName, role string) {
 	params := strings.Split(id, "-")
-	return params[0], params[1], params[2]
+	return strings.ToUpper(params[0]), params[1], params[2]
 }
@@ -13,20 +13,63 @@ func TestAccAccountObjectGrantSnowflake(t *testing.T) {
 			{
 				Config: testSnowflakeAccountObjectGrantConfig,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "object_type", "test_type"),
+					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "object_type", "DATABASE"),
 					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "object_name", "test_name"),
 					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "privileges.#", "1"),
 					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "grant_option", "false"),
 					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "role", "test_role"),
 				),
 			},
+			{
+				Config: testSnowflakeAccountObjectGrantUpdatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("snowflake_account_object_grant.foo", "grant_option", "true"),
+				),
+			},
 		},
 	})
 }
 
 var testSnowflakeAccountObjectGrantConfig = `resource "snowflake_account_object_grant" "foo" {
-	object_type = "test_type"
+	object_type = "DATABASE"
+	object_name = "test_name"
+	privileges = ["USAGE"]
+	role = "test_role"
+}`
+
+var testSnowflakeAccountObjectGrantUpdatedConfig = `resource "snowflake_account_object_grant" "foo" {
+	object_type = "DATABASE"
+	object_name = "test_name"
+	privileges = ["USAGE", "MONITOR"]
+	role = "test_role"
+	grant_option = true
+}`
+
+func TestAccAccountObjectGrantSnowflakeAllWithOtherPrivileges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				// ALL mixed with other privileges only logs a warning and
+				// grants ALL.
+				Config: testSnowflakeAccountObjectGrantAllConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_account_object_grant.all", "privileges.#", "2"),
+				),
+			},
+			{
+				Config:             testSnowflakeAccountObjectGrantAllConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+var testSnowflakeAccountObjectGrantAllConfig = `resource "snowflake_account_object_grant" "all" {
+	object_type = "WAREHOUSE"
 	object_name = "test_name"
-	privileges = ["privilege1"]
+	privileges = ["ALL", "USAGE"]
 	role = "test_role"
 }`
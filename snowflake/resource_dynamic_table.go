@@ -0,0 +1,333 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceDynamicTable() *schema.Resource {
+	return &schema.Resource{
+		Create: createDynamicTable,
+		Read:   readDynamicTable,
+		Update: updateDynamicTable,
+		Delete: deleteDynamicTable,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"query": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The SELECT statement whose results the dynamic table holds",
+				DiffSuppressFunc: suppressStatementDiff,
+			},
+
+			"target_lag": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "How far the contents may lag behind the base tables, e.g. \"5 minutes\", or DOWNSTREAM to refresh only when dependent dynamic tables need it",
+				ValidateFunc:     validateTargetLag,
+				DiffSuppressFunc: suppressTargetLagDiff,
+			},
+
+			"warehouse": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Warehouse running the refreshes",
+			},
+
+			"refresh_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "AUTO",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AUTO", "FULL", "INCREMENTAL"}, false),
+			},
+
+			"actual_refresh_mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "FULL or INCREMENTAL, the mode Snowflake chose when refresh_mode is AUTO",
+			},
+
+			"initialize": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ON_CREATE",
+				ForceNew:     true,
+				Description:  "Whether the table is first filled on creation or on the first scheduled refresh",
+				ValidateFunc: validation.StringInSlice([]string{"ON_CREATE", "ON_SCHEDULE"}, false),
+			},
+
+			"suspended": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether scheduled refreshes are suspended",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func createDynamicTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+	table := qualifiedName(database, schemaName, name)
+
+	stmtSQL := fmt.Sprintf("CREATE DYNAMIC TABLE %s TARGET_LAG = %s WAREHOUSE = \"%s\" REFRESH_MODE = %s INITIALIZE = %s",
+		table,
+		targetLagValue(d.Get("target_lag").(string)),
+		d.Get("warehouse").(string),
+		d.Get("refresh_mode").(string),
+		d.Get("initialize").(string))
+
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	stmtSQL += fmt.Sprintf(" AS %s", d.Get("query").(string))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(dynamicTableIDFromParams(database, schemaName, name))
+
+	if d.Get("suspended").(bool) {
+		if err := alterDynamicTable(meta, table, "SUSPEND"); err != nil {
+			return err
+		}
+	}
+
+	return readDynamicTable(d, meta)
+}
+
+var dynamicTableTextRegexp = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:OR\s+REPLACE\s+)?(?:TRANSIENT\s+)?DYNAMIC\s+TABLE\s+`)
+
+func readDynamicTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromDynamicTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW DYNAMIC TABLES LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("target_lag", row["target_lag"].String)
+		d.Set("warehouse", row["warehouse"].String)
+		d.Set("actual_refresh_mode", row["refresh_mode"].String)
+		// Snowflake reports the mode it chose for AUTO, so a configured AUTO
+		// is kept as it is.
+		if d.Get("refresh_mode").(string) != "AUTO" {
+			d.Set("refresh_mode", row["refresh_mode"].String)
+		}
+		d.Set("suspended", row["scheduling_state"].String == "SUSPENDED")
+		d.Set("comment", row["comment"].String)
+
+		if loc := dynamicTableTextRegexp.FindStringIndex(row["text"].String); loc != nil {
+			if query := statementAfterAS(row["text"].String[loc[1]:]); query != "" {
+				d.Set("query", query)
+			}
+		}
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] dynamic table %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func updateDynamicTable(d *schema.ResourceData, meta interface{}) error {
+	database, schemaName, name, err := paramsFromDynamicTableID(d.Id())
+	if err != nil {
+		return err
+	}
+	table := qualifiedName(database, schemaName, name)
+
+	if d.HasChange("target_lag") {
+		if err := alterDynamicTable(meta, table, fmt.Sprintf("SET TARGET_LAG = %s", targetLagValue(d.Get("target_lag").(string)))); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("warehouse") {
+		if err := alterDynamicTable(meta, table, fmt.Sprintf("SET WAREHOUSE = \"%s\"", d.Get("warehouse").(string))); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("comment") {
+		action := "UNSET COMMENT"
+		if comment := d.Get("comment").(string); comment != "" {
+			action = fmt.Sprintf("SET COMMENT = %s", quoteString(comment))
+		}
+		if err := alterDynamicTable(meta, table, action); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("suspended") {
+		action := "RESUME"
+		if d.Get("suspended").(bool) {
+			action = "SUSPEND"
+		}
+		if err := alterDynamicTable(meta, table, action); err != nil {
+			return err
+		}
+	}
+
+	return readDynamicTable(d, meta)
+}
+
+func deleteDynamicTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromDynamicTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP DYNAMIC TABLE %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func alterDynamicTable(meta interface{}, table, action string) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("ALTER DYNAMIC TABLE %s %s", table, action)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err := db.Exec(stmtSQL)
+	return err
+}
+
+var (
+	targetLagRegexp = regexp.MustCompile(`(?i)^\s*(\d+)\s*(second|minute|hour|day)s?\s*$`)
+
+	targetLagUnits = map[string]int{
+		"second": 1,
+		"minute": 60,
+		"hour":   60 * 60,
+		"day":    24 * 60 * 60,
+	}
+)
+
+// normalizeTargetLag returns DOWNSTREAM, or the lag in seconds, so that e.g.
+// "60 seconds" and "1 minute" compare equal.
+func normalizeTargetLag(lag string) string {
+	if strings.EqualFold(strings.TrimSpace(lag), "DOWNSTREAM") {
+		return "DOWNSTREAM"
+	}
+
+	match := targetLagRegexp.FindStringSubmatch(lag)
+	if match == nil {
+		return lag
+	}
+
+	n, _ := strconv.Atoi(match[1])
+	return strconv.Itoa(n * targetLagUnits[strings.ToLower(match[2])])
+}
+
+// targetLagValue formats a target lag for the TARGET_LAG property.
+func targetLagValue(lag string) string {
+	if normalizeTargetLag(lag) == "DOWNSTREAM" {
+		return "DOWNSTREAM"
+	}
+	return quoteString(strings.TrimSpace(lag))
+}
+
+func validateTargetLag(v interface{}, k string) (ws []string, errors []error) {
+	lag := v.(string)
+	if !strings.EqualFold(strings.TrimSpace(lag), "DOWNSTREAM") && !targetLagRegexp.MatchString(lag) {
+		errors = append(errors, fmt.Errorf("%q must be a number of seconds, minutes, hours or days, e.g. \"5 minutes\", or DOWNSTREAM, got %q", k, lag))
+	}
+	return
+}
+
+func suppressTargetLagDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeTargetLag(old) == normalizeTargetLag(new)
+}
+
+func paramsFromDynamicTableID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func dynamicTableIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
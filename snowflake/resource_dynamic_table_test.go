@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDynamicTableSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeDynamicTableConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_dynamic_table.foo", "name", "test_dynamic_table"),
+					resource.TestCheckResourceAttr("snowflake_dynamic_table.foo", "warehouse", "tf_test_warehouse"),
+					resource.TestCheckResourceAttr("snowflake_dynamic_table.foo", "suspended", "false"),
+					resource.TestCheckResourceAttr("snowflake_dynamic_table.foo", "refresh_mode", "AUTO"),
+					resource.TestCheckResourceAttrSet("snowflake_dynamic_table.foo", "actual_refresh_mode"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeDynamicTableConfig = `resource "snowflake_dynamic_table" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_dynamic_table"
+	query = "SELECT id FROM SAMPLE_TABLE"
+	target_lag = "1 minute"
+	warehouse = "tf_test_warehouse"
+}`
@@ -0,0 +1,381 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var fileFormatTypes = []string{"AVRO", "CSV", "JSON", "ORC", "PARQUET", "XML"}
+
+// fileFormatOptionKind tells how an option is declared in the schema and
+// written in SQL.
+type fileFormatOptionKind int
+
+const (
+	fileFormatString fileFormatOptionKind = iota
+	fileFormatKeyword
+	fileFormatNumber
+	fileFormatBoolean
+	fileFormatList
+)
+
+type fileFormatOption struct {
+	kind        fileFormatOptionKind
+	types       []string
+	description string
+}
+
+// fileFormatOptions are the format type options, keyed by attribute name, with
+// the format types they apply to.
+var fileFormatOptions = map[string]fileFormatOption{
+	"compression":                    {fileFormatKeyword, []string{"AVRO", "CSV", "JSON", "PARQUET", "XML"}, "Compression of the files, e.g. AUTO, GZIP or NONE"},
+	"record_delimiter":               {fileFormatString, []string{"CSV"}, "Characters separating records, or NONE"},
+	"field_delimiter":                {fileFormatString, []string{"CSV"}, "Characters separating fields, or NONE"},
+	"file_extension":                 {fileFormatString, []string{"CSV", "JSON"}, "Extension of files unloaded to a stage"},
+	"parse_header":                   {fileFormatBoolean, []string{"CSV"}, "Whether the first line holds the column names"},
+	"skip_header":                    {fileFormatNumber, []string{"CSV"}, "Number of lines to skip at the start of each file"},
+	"skip_blank_lines":               {fileFormatBoolean, []string{"CSV"}, "Whether blank lines are skipped"},
+	"date_format":                    {fileFormatString, []string{"CSV", "JSON"}, "Format of date values"},
+	"time_format":                    {fileFormatString, []string{"CSV", "JSON"}, "Format of time values"},
+	"timestamp_format":               {fileFormatString, []string{"CSV", "JSON"}, "Format of timestamp values"},
+	"binary_format":                  {fileFormatKeyword, []string{"CSV", "JSON"}, "Encoding of binary values: HEX, BASE64 or UTF8"},
+	"escape":                         {fileFormatString, []string{"CSV"}, "Escape character for enclosed fields, or NONE"},
+	"escape_unenclosed_field":        {fileFormatString, []string{"CSV"}, "Escape character for unenclosed fields, or NONE"},
+	"trim_space":                     {fileFormatBoolean, []string{"AVRO", "CSV", "ORC", "PARQUET"}, "Whether white space around strings is removed"},
+	"field_optionally_enclosed_by":   {fileFormatString, []string{"CSV"}, "Character enclosing strings, or NONE"},
+	"null_if":                        {fileFormatList, []string{"AVRO", "CSV", "JSON", "ORC", "PARQUET"}, "Strings loaded as NULL"},
+	"error_on_column_count_mismatch": {fileFormatBoolean, []string{"CSV"}, "Whether a column count differing from the table is an error"},
+	"replace_invalid_characters":     {fileFormatBoolean, fileFormatTypes, "Whether invalid UTF-8 characters are replaced"},
+	"empty_field_as_null":            {fileFormatBoolean, []string{"CSV"}, "Whether empty fields are loaded as NULL"},
+	"skip_byte_order_mark":           {fileFormatBoolean, []string{"CSV", "XML"}, "Whether a byte order mark at the start of files is skipped"},
+	"encoding":                       {fileFormatString, []string{"CSV"}, "Character set of the files"},
+	"enable_octal":                   {fileFormatBoolean, []string{"JSON"}, "Whether octal numbers are parsed"},
+	"allow_duplicate":                {fileFormatBoolean, []string{"JSON"}, "Whether duplicate object keys are allowed"},
+	"strip_outer_array":              {fileFormatBoolean, []string{"JSON"}, "Whether the outer array is removed, loading each element as a row"},
+	"strip_null_values":              {fileFormatBoolean, []string{"JSON"}, "Whether object fields with null values are removed"},
+	"ignore_utf8_errors":             {fileFormatBoolean, []string{"JSON", "XML"}, "Whether invalid UTF-8 sequences are silently replaced"},
+	"binary_as_text":                 {fileFormatBoolean, []string{"PARQUET"}, "Whether columns without a logical type are read as text"},
+	"preserve_space":                 {fileFormatBoolean, []string{"XML"}, "Whether white space in element content is kept"},
+	"strip_outer_element":            {fileFormatBoolean, []string{"XML"}, "Whether the outer element is removed"},
+	"disable_snowflake_data":         {fileFormatBoolean, []string{"XML"}, "Whether Snowflake semi-structured data tags are not recognised"},
+	"disable_auto_convert":           {fileFormatBoolean, []string{"XML"}, "Whether numeric and boolean values are kept as text"},
+}
+
+func resourceFileFormat() *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"database": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"schema": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"format_type": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(fileFormatTypes, false),
+		},
+
+		"comment": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+
+	// Options left out of the configuration take the value Snowflake uses for
+	// the format type.
+	for name, option := range fileFormatOptions {
+		optionSchema := &schema.Schema{
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("%s. Applies to %s.", option.description, strings.Join(option.types, ", ")),
+		}
+
+		switch option.kind {
+		case fileFormatNumber:
+			optionSchema.Type = schema.TypeInt
+		case fileFormatBoolean:
+			optionSchema.Type = schema.TypeBool
+		case fileFormatList:
+			optionSchema.Type = schema.TypeList
+			optionSchema.Elem = &schema.Schema{Type: schema.TypeString}
+		default:
+			optionSchema.Type = schema.TypeString
+		}
+
+		resourceSchema[name] = optionSchema
+	}
+
+	return &schema.Resource{
+		Create:        createFileFormat,
+		Read:          readFileFormat,
+		Update:        updateFileFormat,
+		Delete:        deleteFileFormat,
+		CustomizeDiff: customizeFileFormatDiff,
+
+		Schema: resourceSchema,
+	}
+}
+
+func createFileFormat(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	stmtSQL := fmt.Sprintf("CREATE FILE FORMAT %s TYPE = %s", qualifiedName(database, schemaName, name), d.Get("format_type").(string))
+
+	for _, option := range sortedFileFormatOptions() {
+		if v, ok := d.GetOkExists(option); ok {
+			stmtSQL += fmt.Sprintf(" %s = %s", strings.ToUpper(option), fileFormatOptionValue(option, v))
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(fileFormatIDFromParams(database, schemaName, name))
+
+	return readFileFormat(d, meta)
+}
+
+func readFileFormat(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromFileFormatID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW FILE FORMATS LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		var formatOptions map[string]interface{}
+		if err := json.Unmarshal([]byte(row["format_options"].String), &formatOptions); err != nil {
+			return fmt.Errorf("Unable to parse format_options of file format %s: %s", name, err)
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("format_type", row["type"].String)
+		d.Set("comment", row["comment"].String)
+
+		for option := range fileFormatOptions {
+			if v, ok := formatOptions[strings.ToUpper(option)]; ok {
+				d.Set(option, fileFormatOptionFromJSON(option, v))
+			}
+		}
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] file format %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func updateFileFormat(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromFileFormatID(d.Id())
+	if err != nil {
+		return err
+	}
+	fileFormat := qualifiedName(database, schemaName, name)
+
+	var options []string
+	for _, option := range sortedFileFormatOptions() {
+		if d.HasChange(option) {
+			options = append(options, fmt.Sprintf("%s = %s", strings.ToUpper(option), fileFormatOptionValue(option, d.Get(option))))
+		}
+	}
+	if d.HasChange("comment") {
+		options = append(options, fmt.Sprintf("COMMENT = %s", quoteString(d.Get("comment").(string))))
+	}
+
+	if len(options) > 0 {
+		stmtSQL := fmt.Sprintf("ALTER FILE FORMAT %s SET %s", fileFormat, strings.Join(options, " "))
+
+		log.Println("Executing statement:", stmtSQL)
+
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readFileFormat(d, meta)
+}
+
+func deleteFileFormat(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromFileFormatID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP FILE FORMAT %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeFileFormatDiff rejects options which do not apply to the format
+// type. On creation every configured option is checked; afterwards only the
+// changed ones are, as state holds the values Snowflake reports for the type.
+func customizeFileFormatDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("format_type") {
+		return nil
+	}
+	formatType := d.Get("format_type").(string)
+
+	for _, option := range sortedFileFormatOptions() {
+		if stringInSlice(formatType, fileFormatOptions[option].types) {
+			continue
+		}
+
+		configured := d.HasChange(option)
+		if d.Id() == "" {
+			_, configured = d.GetOkExists(option)
+		}
+		if configured {
+			return fmt.Errorf("%s does not apply to %s file formats, only to %s", option, formatType, strings.Join(fileFormatOptions[option].types, ", "))
+		}
+	}
+
+	return nil
+}
+
+// fileFormatOptionValue formats an option value for CREATE and ALTER FILE
+// FORMAT.
+func fileFormatOptionValue(option string, v interface{}) string {
+	switch fileFormatOptions[option].kind {
+	case fileFormatNumber:
+		return fmt.Sprintf("%d", v.(int))
+	case fileFormatBoolean:
+		return fmt.Sprintf("%t", v.(bool))
+	case fileFormatKeyword:
+		return strings.ToUpper(v.(string))
+	case fileFormatList:
+		var values []string
+		for _, value := range v.([]interface{}) {
+			values = append(values, quoteString(value.(string)))
+		}
+		return fmt.Sprintf("(%s)", strings.Join(values, ", "))
+	}
+
+	// NONE turns delimiters and escape characters off and must not be quoted.
+	if value := v.(string); value != "NONE" {
+		return quoteString(value)
+	}
+	return "NONE"
+}
+
+// fileFormatOptionFromJSON converts a value of the format_options JSON shown by
+// SHOW FILE FORMATS to its value in state.
+func fileFormatOptionFromJSON(option string, v interface{}) interface{} {
+	switch fileFormatOptions[option].kind {
+	case fileFormatNumber:
+		if n, ok := v.(float64); ok {
+			return int(n)
+		}
+		return 0
+	case fileFormatBoolean:
+		b, _ := v.(bool)
+		return b
+	case fileFormatList:
+		var values []interface{}
+		if list, ok := v.([]interface{}); ok {
+			for _, value := range list {
+				values = append(values, fmt.Sprintf("%v", value))
+			}
+		}
+		return values
+	}
+
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// sortedFileFormatOptions returns the option names in lexical order, so that
+// generated statements are stable between runs.
+func sortedFileFormatOptions() []string {
+	options := make([]string, 0, len(fileFormatOptions))
+	for option := range fileFormatOptions {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	return options
+}
+
+func paramsFromFileFormatID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func fileFormatIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
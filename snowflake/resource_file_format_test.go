@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccFileFormatSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeFileFormatConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_file_format.foo", "format_type", "CSV"),
+					resource.TestCheckResourceAttr("snowflake_file_format.foo", "field_delimiter", "|"),
+					resource.TestCheckResourceAttr("snowflake_file_format.foo", "skip_header", "1"),
+					resource.TestCheckResourceAttr("snowflake_file_format.foo", "null_if.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeFileFormatConfig = `resource "snowflake_file_format" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_file_format"
+	format_type = "CSV"
+	field_delimiter = "|"
+	skip_header = 1
+	null_if = ["NULL"]
+}`
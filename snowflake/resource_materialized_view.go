@@ -0,0 +1,319 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceMaterializedView() *schema.Resource {
+	return &schema.Resource{
+		Create: createMaterializedView,
+		Read:   readMaterializedView,
+		Update: updateMaterializedView,
+		Delete: deleteMaterializedView,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"statement": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The SELECT statement defining the materialized view",
+				DiffSuppressFunc: suppressStatementDiff,
+			},
+
+			"warehouse": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Warehouse used to build the materialized view when it is created or replaced. Maintenance runs on Snowflake-managed compute.",
+			},
+
+			"cluster_by": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Expressions making up the clustering key",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"is_secure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"suspended": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether maintenance of the materialized view is suspended",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func createMaterializedView(d *schema.ResourceData, meta interface{}) error {
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	if err := replaceMaterializedView(d, meta, false); err != nil {
+		return err
+	}
+
+	d.SetId(materializedViewIDFromParams(database, schemaName, name))
+
+	if d.Get("suspended").(bool) {
+		if err := alterMaterializedView(meta, qualifiedName(database, schemaName, name), "SUSPEND"); err != nil {
+			return err
+		}
+	}
+
+	return readMaterializedView(d, meta)
+}
+
+// replaceMaterializedView runs CREATE MATERIALIZED VIEW with the configured
+// statement. The statement runs on a single connection so that the warehouse
+// selected for building the view is the one in use when it is created.
+func replaceMaterializedView(d *schema.ResourceData, meta interface{}, orReplace bool) error {
+	db := meta.(*providerConfiguration).DB
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if warehouse, ok := d.GetOk("warehouse"); ok {
+		stmtSQL := fmt.Sprintf("USE WAREHOUSE \"%s\"", warehouse.(string))
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := conn.ExecContext(ctx, stmtSQL); err != nil {
+			return err
+		}
+
+		// USE WAREHOUSE changes the session of the connection, so it is
+		// discarded rather than returned to the pool for other statements.
+		defer conn.Raw(func(interface{}) error {
+			return driver.ErrBadConn
+		})
+	}
+
+	stmtSQL := "CREATE"
+	if orReplace {
+		stmtSQL += " OR REPLACE"
+	}
+	if d.Get("is_secure").(bool) {
+		stmtSQL += " SECURE"
+	}
+	stmtSQL += fmt.Sprintf(" MATERIALIZED VIEW %s", qualifiedName(d.Get("database").(string), d.Get("schema").(string), d.Get("name").(string)))
+
+	if orReplace {
+		stmtSQL += " COPY GRANTS"
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+	if v, ok := d.GetOk("cluster_by"); ok {
+		stmtSQL += fmt.Sprintf(" CLUSTER BY (%s)", listToString(v.([]interface{})))
+	}
+
+	stmtSQL += fmt.Sprintf(" AS %s", d.Get("statement").(string))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	_, err = conn.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+func readMaterializedView(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromMaterializedViewID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW MATERIALIZED VIEWS LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("is_secure", strings.ToLower(row["is_secure"].String) == "true")
+		d.Set("comment", row["comment"].String)
+		d.Set("cluster_by", parseClusterBy(row["cluster_by"].String))
+
+		// A suspended materialized view is reported as invalid, with the
+		// suspension as the reason.
+		d.Set("suspended", strings.ToLower(row["invalid"].String) == "true" &&
+			strings.Contains(strings.ToLower(row["invalid_reason"].String), "suspend"))
+
+		if statement := viewStatementFromText(row["text"].String); statement != "" {
+			d.Set("statement", statement)
+		}
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] materialized view %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func updateMaterializedView(d *schema.ResourceData, meta interface{}) error {
+	database, schemaName, name, err := paramsFromMaterializedViewID(d.Id())
+	if err != nil {
+		return err
+	}
+	view := qualifiedName(database, schemaName, name)
+
+	if d.HasChange("statement") {
+		// Replacing the view sets every other property from the
+		// configuration, and a new materialized view is never suspended.
+		if err := replaceMaterializedView(d, meta, true); err != nil {
+			return err
+		}
+		if d.Get("suspended").(bool) {
+			if err := alterMaterializedView(meta, view, "SUSPEND"); err != nil {
+				return err
+			}
+		}
+		return readMaterializedView(d, meta)
+	}
+
+	if d.HasChange("is_secure") {
+		action := "UNSET SECURE"
+		if d.Get("is_secure").(bool) {
+			action = "SET SECURE"
+		}
+		if err := alterMaterializedView(meta, view, action); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("cluster_by") {
+		action := "DROP CLUSTERING KEY"
+		if clusterBy := d.Get("cluster_by").([]interface{}); len(clusterBy) > 0 {
+			action = fmt.Sprintf("CLUSTER BY (%s)", listToString(clusterBy))
+		}
+		if err := alterMaterializedView(meta, view, action); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("comment") {
+		action := "UNSET COMMENT"
+		if comment := d.Get("comment").(string); comment != "" {
+			action = fmt.Sprintf("SET COMMENT = %s", quoteString(comment))
+		}
+		if err := alterMaterializedView(meta, view, action); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("suspended") {
+		action := "RESUME"
+		if d.Get("suspended").(bool) {
+			action = "SUSPEND"
+		}
+		if err := alterMaterializedView(meta, view, action); err != nil {
+			return err
+		}
+	}
+
+	return readMaterializedView(d, meta)
+}
+
+func deleteMaterializedView(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromMaterializedViewID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP MATERIALIZED VIEW %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func alterMaterializedView(meta interface{}, view, action string) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("ALTER MATERIALIZED VIEW %s %s", view, action)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err := db.Exec(stmtSQL)
+	return err
+}
+
+func paramsFromMaterializedViewID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func materializedViewIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccMaterializedViewSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeMaterializedViewConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_materialized_view.foo", "name", "test_materialized_view"),
+					resource.TestCheckResourceAttr("snowflake_materialized_view.foo", "cluster_by.#", "1"),
+					resource.TestCheckResourceAttr("snowflake_materialized_view.foo", "suspended", "false"),
+					resource.TestCheckResourceAttr("snowflake_materialized_view.foo", "comment", "test materialized view"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeMaterializedViewConfig = `resource "snowflake_materialized_view" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_materialized_view"
+	statement = "SELECT id FROM SAMPLE_TABLE"
+	warehouse = "tf_test_warehouse"
+	cluster_by = ["ID"]
+	comment = "test materialized view"
+}`
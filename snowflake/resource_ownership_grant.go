@@ -0,0 +1,296 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// ownershipObjectTypes returns every object type whose ownership can be
+// transferred with snowflake_ownership_grant.
+func ownershipObjectTypes() []string {
+	types := append(objectTypes(accountObjectPrivileges), objectTypes(schemaObjectPrivileges)...)
+	return append(types, "ROLE", "SCHEMA", "USER")
+}
+
+func resourceOwnershipGrant() *schema.Resource {
+	return &schema.Resource{
+		Create:        createOwnershipGrant,
+		Update:        updateOwnershipGrant,
+		Read:          readOwnershipGrant,
+		Delete:        deleteOwnershipGrant,
+		CustomizeDiff: customizeOwnershipGrantDiff,
+
+		Schema: map[string]*schema.Schema{
+			"object_type": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(ownershipObjectTypes(), true),
+				DiffSuppressFunc: suppressCaseDiff,
+			},
+
+			"object_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the object. When omitted, ownership of all (or future) objects of the type in the database or schema is transferred.",
+			},
+
+			"database": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Database of the object, for schemas and objects which live in a schema",
+			},
+
+			"schema": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Schema of the object, for objects which live in a schema",
+			},
+
+			"future": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"role": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The role which should own the objects",
+			},
+
+			"copy_current_grants": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"revoke_current_grants"},
+				Description:   "Keep the grants which exist on the objects when transferring ownership",
+			},
+
+			"revoke_current_grants": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"copy_current_grants"},
+				Description:   "Revoke the grants which exist on the objects when transferring ownership",
+			},
+
+			"revert_ownership_to_role": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Role to which ownership is transferred back on destroy. When empty, ownership is left unchanged.",
+			},
+		},
+	}
+}
+
+func createOwnershipGrant(d *schema.ResourceData, meta interface{}) error {
+	var (
+		objectType   = strings.ToUpper(d.Get("object_type").(string))
+		objectName   = d.Get("object_name").(string)
+		databaseName = d.Get("database").(string)
+		schemaName   = d.Get("schema").(string)
+		future       = d.Get("future").(bool)
+	)
+
+	if err := transferOwnership(d, meta, d.Get("role").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(generateOwnershipGrantID(objectType, objectName, databaseName, schemaName, future))
+
+	return readOwnershipGrant(d, meta)
+}
+
+func readOwnershipGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, databaseName, schemaName, future, err := getParamsFromOwnershipGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set("object_type", objectType)
+	d.Set("object_name", objectName)
+	d.Set("database", databaseName)
+	d.Set("schema", schemaName)
+	d.Set("future", future)
+
+	// Ownership of all existing objects is a one-off transfer which cannot be
+	// read back as a single grant, so the state is kept as it is.
+	if !future && objectName == "" {
+		return nil
+	}
+
+	var (
+		createdOn   string
+		privilege   string
+		grantedOn   string
+		name        string
+		grantedTo   string
+		granteeName string
+		grantOption bool
+		grantedBy   string
+		stmtSQL     string
+	)
+
+	if future {
+		if schemaName != "" {
+			stmtSQL = fmt.Sprintf("SHOW FUTURE GRANTS IN SCHEMA %s", qualifiedName(databaseName, schemaName))
+		} else {
+			stmtSQL = fmt.Sprintf("SHOW FUTURE GRANTS IN DATABASE %s", qualifiedName(databaseName))
+		}
+	} else {
+		stmtSQL = fmt.Sprintf("SHOW GRANTS ON %s", generateOwnershipTarget(objectType, objectName, databaseName, schemaName, future))
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		if future {
+			err = rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption)
+		} else {
+			err = rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption, &grantedBy)
+		}
+		if err != nil {
+			return err
+		}
+
+		if privilege == "OWNERSHIP" && grantedTo == "ROLE" && (!future || isGrantedOn(grantedOn, objectType)) {
+			d.Set("role", granteeName)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The ownership grant on %s does not exist.", generateOwnershipTarget(objectType, objectName, databaseName, schemaName, future))
+}
+
+func updateOwnershipGrant(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("role") {
+		if err := transferOwnership(d, meta, d.Get("role").(string)); err != nil {
+			return err
+		}
+	}
+
+	return readOwnershipGrant(d, meta)
+}
+
+func deleteOwnershipGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, databaseName, schemaName, future, err := getParamsFromOwnershipGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if revertTo := d.Get("revert_ownership_to_role").(string); revertTo != "" {
+		if err := transferOwnership(d, meta, revertTo); err != nil {
+			return err
+		}
+	} else if future {
+		stmtSQL := fmt.Sprintf("REVOKE OWNERSHIP ON %s FROM ROLE \"%s\"",
+			generateOwnershipTarget(objectType, objectName, databaseName, schemaName, future),
+			d.Get("role").(string))
+
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[WARN] ownership of %s is left with role %s, set revert_ownership_to_role to transfer it on destroy",
+			generateOwnershipTarget(objectType, objectName, databaseName, schemaName, future), d.Get("role").(string))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func transferOwnership(d *schema.ResourceData, meta interface{}, role string) error {
+	db := meta.(*providerConfiguration).DB
+
+	future := d.Get("future").(bool)
+	stmtSQL := fmt.Sprintf("GRANT OWNERSHIP ON %s TO ROLE \"%s\"",
+		generateOwnershipTarget(d.Get("object_type").(string), d.Get("object_name").(string), d.Get("database").(string), d.Get("schema").(string), future),
+		role)
+
+	if !future {
+		if d.Get("copy_current_grants").(bool) {
+			stmtSQL += " COPY CURRENT GRANTS"
+		} else if d.Get("revoke_current_grants").(bool) {
+			stmtSQL += " REVOKE CURRENT GRANTS"
+		}
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err := db.Exec(stmtSQL)
+	return err
+}
+
+// customizeOwnershipGrantDiff checks that the combination of object_name,
+// database, schema and future describes something ownership can be granted
+// on.
+func customizeOwnershipGrantDiff(d *schema.ResourceDiff, meta interface{}) error {
+	objectName := d.Get("object_name").(string)
+	databaseName := d.Get("database").(string)
+	future := d.Get("future").(bool)
+
+	if future && objectName != "" {
+		return fmt.Errorf("object_name cannot be set for future ownership grants")
+	}
+	if future && (d.Get("copy_current_grants").(bool) || d.Get("revoke_current_grants").(bool)) {
+		return fmt.Errorf("copy_current_grants and revoke_current_grants do not apply to future ownership grants")
+	}
+	if objectName == "" && databaseName == "" && d.NewValueKnown("object_name") && d.NewValueKnown("database") {
+		return fmt.Errorf("database must be set when object_name is omitted")
+	}
+
+	return nil
+}
+
+func generateOwnershipTarget(objectType, objectName, database, schema string, future bool) string {
+	if objectName != "" {
+		var parts []string
+		for _, part := range []string{database, schema, objectName} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return fmt.Sprintf("%s %s", objectType, qualifiedName(parts...))
+	}
+
+	scope := fmt.Sprintf("DATABASE %s", qualifiedName(database))
+	if schema != "" {
+		scope = fmt.Sprintf("SCHEMA %s", qualifiedName(database, schema))
+	}
+
+	if future {
+		return fmt.Sprintf("FUTURE %sS IN %s", objectType, scope)
+	}
+	return fmt.Sprintf("ALL %sS IN %s", objectType, scope)
+}
+
+func generateOwnershipGrantID(objectType, objectName, database, schema string, future bool) string {
+	return idFromParams(objectType, objectName, database, schema, strconv.FormatBool(future))
+}
+
+func getParamsFromOwnershipGrantID(id string) (objectType, objectName, database, schema string, future bool, err error) {
+	params, err := paramsFromID(id, 5)
+	if err != nil {
+		return "", "", "", "", false, err
+	}
+	future, _ = strconv.ParseBool(params[4])
+	return strings.ToUpper(params[0]), params[1], params[2], params[3], future, nil
+}
@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccOwnershipGrantSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeOwnershipGrantConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_ownership_grant.foo", "object_type", "TABLE"),
+					resource.TestCheckResourceAttr("snowflake_ownership_grant.foo", "object_name", "SAMPLE_TABLE"),
+					resource.TestCheckResourceAttr("snowflake_ownership_grant.foo", "role", "test_role"),
+					resource.TestCheckResourceAttr("snowflake_ownership_grant.foo", "copy_current_grants", "true"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeOwnershipGrantConfig = `resource "snowflake_ownership_grant" "foo" {
+	object_type = "TABLE"
+	object_name = "SAMPLE_TABLE"
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	role = "test_role"
+	copy_current_grants = true
+	revert_ownership_to_role = "SYSADMIN"
+}`
@@ -0,0 +1,250 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourcePipe() *schema.Resource {
+	return &schema.Resource{
+		Create: createPipe,
+		Read:   readPipe,
+		Update: updatePipe,
+		Delete: deletePipe,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"copy_statement": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The COPY INTO statement run by the pipe",
+				DiffSuppressFunc: suppressStatementDiff,
+			},
+
+			"auto_ingest": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether files are loaded when event notifications arrive from the stage's cloud storage",
+			},
+
+			"aws_sns_topic_arn": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "SNS topic receiving the S3 event notifications, when S3 sends them through SNS",
+			},
+
+			"integration": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Notification integration used for auto ingest from Google Cloud Storage or Azure",
+				DiffSuppressFunc: suppressCaseDiff,
+			},
+
+			"error_integration": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Notification integration receiving load error notifications",
+				DiffSuppressFunc: suppressCaseDiff,
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"notification_channel": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The queue to send S3 event notifications to, for auto ingest pipes",
+			},
+
+			"owner": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role owning the pipe",
+			},
+		},
+	}
+}
+
+func createPipe(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	stmtSQL := fmt.Sprintf("CREATE PIPE %s", qualifiedName(database, schemaName, name))
+
+	if d.Get("auto_ingest").(bool) {
+		stmtSQL += " AUTO_INGEST = TRUE"
+	}
+	if v, ok := d.GetOk("aws_sns_topic_arn"); ok {
+		stmtSQL += fmt.Sprintf(" AWS_SNS_TOPIC = %s", quoteString(v.(string)))
+	}
+	// Integration names are used as unquoted identifiers, which Snowflake
+	// resolves upper-cased, so they are compared ignoring case. INTEGRATION
+	// takes the name as a string and is given the resolved form.
+	if v, ok := d.GetOk("integration"); ok {
+		stmtSQL += fmt.Sprintf(" INTEGRATION = %s", quoteString(strings.ToUpper(v.(string))))
+	}
+	if v, ok := d.GetOk("error_integration"); ok {
+		stmtSQL += fmt.Sprintf(" ERROR_INTEGRATION = %s", v.(string))
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	stmtSQL += fmt.Sprintf(" AS %s", d.Get("copy_statement").(string))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(pipeIDFromParams(database, schemaName, name))
+
+	return readPipe(d, meta)
+}
+
+func readPipe(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromPipeID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW PIPES LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("copy_statement", row["definition"].String)
+		d.Set("comment", row["comment"].String)
+		d.Set("integration", row["integration"].String)
+		d.Set("error_integration", row["error_integration"].String)
+		d.Set("notification_channel", row["notification_channel"].String)
+		d.Set("owner", row["owner"].String)
+
+		// Auto ingest pipes on S3 get a notification channel, and those on
+		// Google Cloud Storage or Azure need a notification integration.
+		d.Set("auto_ingest", row["notification_channel"].String != "" || row["integration"].String != "")
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] pipe %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func updatePipe(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromPipeID(d.Id())
+	if err != nil {
+		return err
+	}
+	pipe := qualifiedName(database, schemaName, name)
+
+	if d.HasChange("comment") {
+		stmtSQL := fmt.Sprintf("ALTER PIPE %s UNSET COMMENT", pipe)
+		if comment := d.Get("comment").(string); comment != "" {
+			stmtSQL = fmt.Sprintf("ALTER PIPE %s SET COMMENT = %s", pipe, quoteString(comment))
+		}
+
+		log.Println("Executing statement:", stmtSQL)
+
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readPipe(d, meta)
+}
+
+func deletePipe(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromPipeID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP PIPE %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func paramsFromPipeID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func pipeIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
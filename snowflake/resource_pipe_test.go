@@ -0,0 +1,32 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccPipeSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakePipeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_pipe.foo", "name", "test_pipe"),
+					resource.TestCheckResourceAttr("snowflake_pipe.foo", "auto_ingest", "false"),
+					resource.TestCheckResourceAttr("snowflake_pipe.foo", "comment", "test pipe"),
+					resource.TestCheckResourceAttrSet("snowflake_pipe.foo", "owner"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakePipeConfig = `resource "snowflake_pipe" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_pipe"
+	copy_statement = "COPY INTO SAMPLE_TABLE FROM @SAMPLE_STAGE"
+	comment = "test pipe"
+}`
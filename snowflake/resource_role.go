@@ -3,6 +3,7 @@ package snowflake
 import (
 	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -18,12 +19,29 @@ func resourceRole() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"comment": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Role which owns this role. Ownership is transferred keeping the current grants.",
+			},
+			"assigned_to_users": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"granted_to_roles": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"granted_roles": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -46,33 +64,68 @@ func createRole(d *schema.ResourceData, meta interface{}) error {
 	name := d.Get("name").(string)
 	d.SetId(name)
 
+	if v, ok := d.GetOk("owner"); ok {
+		if err := transferRoleOwnership(d, meta, v.(string)); err != nil {
+			return err
+		}
+	}
+
 	return readRole(d, meta)
 }
 
 func updateRole(d *schema.ResourceData, meta interface{}) error {
-	if !d.HasChange("comment") {
-		return nil
+	db := meta.(*providerConfiguration).DB
+
+	if d.HasChange("name") {
+		_, newName := d.GetChange("name")
+
+		stmtSQL := fmt.Sprintf("ALTER ROLE \"%s\" RENAME TO \"%s\"", d.Id(), newName.(string))
+
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+
+		d.SetId(newName.(string))
 	}
 
-	var stmtSQL string
-	db := meta.(*providerConfiguration).DB
-	_, newComment := d.GetChange("comment")
-
-	if newComment.(string) == "" {
-		stmtSQL = fmt.Sprintf("ALTER ROLE \"%s\" UNSET COMMENT", d.Id())
-	} else {
-		stmtSQL = fmt.Sprintf("ALTER ROLE \"%s\" SET COMMENT = \"%s\"",
-			d.Id(),
-			newComment.(string))
+	if d.HasChange("comment") {
+		var stmtSQL string
+		_, newComment := d.GetChange("comment")
+
+		if newComment.(string) == "" {
+			stmtSQL = fmt.Sprintf("ALTER ROLE \"%s\" UNSET COMMENT", d.Id())
+		} else {
+			stmtSQL = fmt.Sprintf("ALTER ROLE \"%s\" SET COMMENT = \"%s\"",
+				d.Id(),
+				newComment.(string))
+		}
+
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
 	}
 
-	log.Println("Executing statement:", stmtSQL)
-	_, err := db.Exec(stmtSQL)
-	if err != nil {
-		return err
+	if d.HasChange("owner") {
+		if newOwner, ok := d.GetOk("owner"); ok {
+			if err := transferRoleOwnership(d, meta, newOwner.(string)); err != nil {
+				return err
+			}
+		}
 	}
 
-	return nil
+	return readRole(d, meta)
+}
+
+func transferRoleOwnership(d *schema.ResourceData, meta interface{}, owner string) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("GRANT OWNERSHIP ON ROLE \"%s\" TO ROLE \"%s\" COPY CURRENT GRANTS", d.Id(), owner)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err := db.Exec(stmtSQL)
+	return err
 }
 
 func readRole(d *schema.ResourceData, meta interface{}) error {
@@ -110,6 +163,19 @@ func readRole(d *schema.ResourceData, meta interface{}) error {
 		if name == d.Id() {
 			d.Set("name", name)
 			d.Set("comment", comment)
+			d.Set("owner", owner)
+
+			for attr, count := range map[string]string{
+				"assigned_to_users": assignedTo,
+				"granted_to_roles":  grantedToRoles,
+				"granted_roles":     grantedRoles,
+			} {
+				n, err := strconv.Atoi(count)
+				if err != nil {
+					return fmt.Errorf("Unexpected value %q for %s of role %s: %s", count, attr, name, err)
+				}
+				d.Set(attr, n)
+			}
 			return nil
 		}
 	}
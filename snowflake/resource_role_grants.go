@@ -0,0 +1,197 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRoleGrants() *schema.Resource {
+	return &schema.Resource{
+		Create: createRoleGrants,
+		Read:   readRoleGrants,
+		Update: updateRoleGrants,
+		Delete: deleteRoleGrants,
+
+		Schema: map[string]*schema.Schema{
+			"role_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role whose members are managed",
+				ForceNew:    true,
+			},
+
+			"users": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Users to which the role is granted. Any other user is revoked.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"roles": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Roles to which the role is granted. Any other role is revoked.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+	}
+}
+
+func createRoleGrants(d *schema.ResourceData, meta interface{}) error {
+	role := d.Get("role_name").(string)
+
+	// The role may already be granted outside of terraform, so its members
+	// are reconciled the same way as on update.
+	users, roles, err := roleGrantees(meta, role)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]*schema.Set{
+		"users": schema.NewSet(schema.HashString, users),
+		"roles": schema.NewSet(schema.HashString, roles),
+	}
+
+	for attr, granteeType := range map[string]string{"users": "USER", "roles": "ROLE"} {
+		grantees := d.Get(attr).(*schema.Set)
+
+		for _, grantee := range current[attr].Difference(grantees).List() {
+			if err := revokeRoleFrom(meta, role, granteeType, grantee.(string)); err != nil {
+				return err
+			}
+		}
+
+		for _, grantee := range grantees.Difference(current[attr]).List() {
+			if err := grantRoleTo(meta, role, granteeType, grantee.(string)); err != nil {
+				return err
+			}
+		}
+	}
+
+	d.SetId(role)
+
+	return readRoleGrants(d, meta)
+}
+
+func readRoleGrants(d *schema.ResourceData, meta interface{}) error {
+	role := d.Id()
+
+	users, roles, err := roleGrantees(meta, role)
+	if err != nil {
+		return err
+	}
+
+	d.Set("role_name", role)
+	d.Set("users", schema.NewSet(schema.HashString, users))
+	d.Set("roles", schema.NewSet(schema.HashString, roles))
+	return nil
+}
+
+// roleGrantees returns the users and roles to which role is granted.
+func roleGrantees(meta interface{}, role string) (users, roles []interface{}, err error) {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("SHOW GRANTS OF ROLE \"%s\"", role)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbCreatedAt string
+		var dbRole string
+		var dbGrantedTo string
+		var dbGranteeName string
+		var dbGrantedBy string
+		if err := rows.Scan(&dbCreatedAt, &dbRole, &dbGrantedTo, &dbGranteeName, &dbGrantedBy); err != nil {
+			return nil, nil, err
+		}
+
+		switch dbGrantedTo {
+		case "USER":
+			users = append(users, dbGranteeName)
+		case "ROLE":
+			roles = append(roles, dbGranteeName)
+		}
+	}
+
+	return users, roles, rows.Err()
+}
+
+func updateRoleGrants(d *schema.ResourceData, meta interface{}) error {
+	role := d.Id()
+
+	for attr, granteeType := range map[string]string{"users": "USER", "roles": "ROLE"} {
+		if !d.HasChange(attr) {
+			continue
+		}
+
+		o, n := d.GetChange(attr)
+		oldGrantees := o.(*schema.Set)
+		newGrantees := n.(*schema.Set)
+
+		for _, grantee := range oldGrantees.Difference(newGrantees).List() {
+			if err := revokeRoleFrom(meta, role, granteeType, grantee.(string)); err != nil {
+				return err
+			}
+		}
+
+		for _, grantee := range newGrantees.Difference(oldGrantees).List() {
+			if err := grantRoleTo(meta, role, granteeType, grantee.(string)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return readRoleGrants(d, meta)
+}
+
+func deleteRoleGrants(d *schema.ResourceData, meta interface{}) error {
+	role := d.Id()
+
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		if err := revokeRoleFrom(meta, role, "USER", user.(string)); err != nil {
+			return err
+		}
+	}
+
+	for _, grantee := range d.Get("roles").(*schema.Set).List() {
+		if err := revokeRoleFrom(meta, role, "ROLE", grantee.(string)); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func grantRoleTo(meta interface{}, role, granteeType, grantee string) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("GRANT ROLE \"%s\" TO %s \"%s\"", role, granteeType, grantee)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	_, err := db.Exec(stmtSQL)
+	return err
+}
+
+func revokeRoleFrom(meta interface{}, role, granteeType, grantee string) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("REVOKE ROLE \"%s\" FROM %s \"%s\"", role, granteeType, grantee)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	_, err := db.Exec(stmtSQL)
+	return err
+}
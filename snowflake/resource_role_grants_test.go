@@ -0,0 +1,31 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRoleGrantsSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeRoleGrantsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_role_grants.test", "role_name", "tf-test-role"),
+					resource.TestCheckResourceAttr("snowflake_role_grants.test", "users.#", "1"),
+					resource.TestCheckResourceAttr("snowflake_role_grants.test", "roles.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeRoleGrantsConfig = `
+resource "snowflake_role_grants" "test" {
+  role_name = "tf-test-role"
+  users     = ["tf-test-user"]
+  roles     = ["SYSADMIN"]
+}
+`
@@ -0,0 +1,224 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceRoleHierarchyGrant() *schema.Resource {
+	return &schema.Resource{
+		Create:        createRoleHierarchyGrant,
+		Read:          readRoleHierarchyGrant,
+		Delete:        deleteRoleHierarchyGrant,
+		CustomizeDiff: customizeRoleHierarchyGrantDiff,
+
+		Schema: map[string]*schema.Schema{
+			"role": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role to grant",
+				ForceNew:    true,
+			},
+
+			"parent_role": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The role to which this role should be granted, inheriting its privileges",
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func createRoleHierarchyGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	role := d.Get("role").(string)
+	parentRole := d.Get("parent_role").(string)
+
+	d.SetId(roleHierarchyGrantIDFromParams(role, parentRole))
+
+	stmtSQL := fmt.Sprintf("GRANT ROLE \"%s\" TO ROLE \"%s\"", role, parentRole)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	return readRoleHierarchyGrant(d, meta)
+}
+
+func readRoleHierarchyGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	role, parentRole, err := paramsFromRoleHierarchyGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW GRANTS OF ROLE \"%s\"", role)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbCreatedAt string
+		var dbRole string
+		var dbGrantedTo string
+		var dbGranteeName string
+		var dbGrantedBy string
+		if err := rows.Scan(&dbCreatedAt, &dbRole, &dbGrantedTo, &dbGranteeName, &dbGrantedBy); err != nil {
+			return err
+		}
+		if dbGrantedTo == "ROLE" && dbGranteeName == parentRole {
+			d.Set("role", dbRole)
+			d.Set("parent_role", dbGranteeName)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The grant of role %s to role %s does not exist.", role, parentRole)
+}
+
+func deleteRoleHierarchyGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	role, parentRole, err := paramsFromRoleHierarchyGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("REVOKE ROLE \"%s\" FROM ROLE \"%s\"", role, parentRole)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	_, err = db.Exec(stmtSQL)
+	if err == nil {
+		d.SetId("")
+	}
+
+	return err
+}
+
+// customizeRoleHierarchyGrantDiff refuses to plan a grant which would make a
+// role inherit from itself, either directly or because the parent role is
+// already granted to the role somewhere below it in the hierarchy. Only grants
+// which already exist are seen, not others planned in the same apply.
+func customizeRoleHierarchyGrantDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("role") || !d.NewValueKnown("parent_role") {
+		return nil
+	}
+
+	role := d.Get("role").(string)
+	parentRole := d.Get("parent_role").(string)
+
+	if role == parentRole {
+		return fmt.Errorf("Role %s cannot be granted to itself", role)
+	}
+
+	inherits, err := roleInheritsFrom(meta.(*providerConfiguration), role, parentRole)
+	if err != nil {
+		return err
+	}
+	if inherits {
+		return fmt.Errorf("Granting role %s to role %s would create a cycle: %s is already granted to %s", role, parentRole, parentRole, role)
+	}
+
+	return nil
+}
+
+// roleInheritsFrom reports whether ancestor is granted, directly or through
+// other roles, to role.
+func roleInheritsFrom(conf *providerConfiguration, role, ancestor string) (bool, error) {
+	visited := map[string]bool{role: true}
+	pending := []string{role}
+
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+
+		stmtSQL := fmt.Sprintf("SHOW GRANTS TO ROLE \"%s\"", current)
+
+		log.Println("Executing statement:", stmtSQL)
+
+		rows, err := conf.DB.Query(stmtSQL)
+		if isDoesNotExistError(err) {
+			// The role is created in the same apply, so nothing is granted
+			// to it yet.
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		for rows.Next() {
+			var (
+				createdOn   string
+				privilege   string
+				grantedOn   string
+				name        string
+				grantedTo   string
+				granteeName string
+				grantOption bool
+				grantedBy   string
+			)
+			if err := rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption, &grantedBy); err != nil {
+				rows.Close()
+				return false, err
+			}
+
+			if grantedOn != "ROLE" || privilege != "USAGE" {
+				continue
+			}
+			if name == ancestor {
+				rows.Close()
+				return true, nil
+			}
+			if !visited[name] {
+				visited[name] = true
+				pending = append(pending, name)
+			}
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// isDoesNotExistError reports whether err is Snowflake's error for a missing
+// or inaccessible object.
+func isDoesNotExistError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "does not exist")
+}
+
+// Role names may contain "-", so the parts of the ID are separated by "|".
+// IDs written with "-" by earlier versions are still read when unambiguous.
+func paramsFromRoleHierarchyGrantID(id string) (role, parentRole string, err error) {
+	splits := strings.Split(id, "|")
+	if len(splits) != 2 {
+		splits = strings.Split(id, "-")
+	}
+	if len(splits) != 2 {
+		return "", "", fmt.Errorf("invalid role hierarchy grant ID %q, expected role|parent_role", id)
+	}
+	return splits[0], splits[1], nil
+}
+
+func roleHierarchyGrantIDFromParams(role, parentRole string) string {
+	return fmt.Sprintf("%s|%s", role, parentRole)
+}
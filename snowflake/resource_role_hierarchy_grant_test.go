@@ -0,0 +1,30 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccRoleHierarchyGrantSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeRoleHierarchyGrantConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_role_hierarchy_grant.test", "role", "tf-test-role"),
+					resource.TestCheckResourceAttr("snowflake_role_hierarchy_grant.test", "parent_role", "SYSADMIN"),
+					resource.TestCheckResourceAttr("snowflake_role_hierarchy_grant.test", "id", "tf-test-role|SYSADMIN"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeRoleHierarchyGrantConfig = `
+resource "snowflake_role_hierarchy_grant" "test" {
+  role        = "tf-test-role"
+  parent_role = "SYSADMIN"
+}
+`
@@ -14,6 +14,7 @@ func TestAccRoleSnowflakeDatabase(t *testing.T) {
 				Config: testSnowflakeRoleConfig,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("snowflake_role.foo", "name", "tf-test"),
+					resource.TestCheckResourceAttr("snowflake_role.foo", "assigned_to_users", "0"),
 				),
 			},
 		},
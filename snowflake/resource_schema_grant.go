@@ -3,6 +3,7 @@ package snowflake
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -10,16 +11,18 @@ import (
 
 func resourceSchemaGrant() *schema.Resource {
 	return &schema.Resource{
-		Create: createSchemaGrant,
-		Update: nil,
-		Read:   readSchemaGrant,
-		Delete: deleteSchemaGrant,
+		Create:        createSchemaGrant,
+		Update:        updateSchemaGrant,
+		Read:          readSchemaGrant,
+		Delete:        deleteSchemaGrant,
+		CustomizeDiff: customizeSchemaGrantDiff,
 
 		Schema: map[string]*schema.Schema{
 			"schema": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the schema, or ALL for all schemas in the database. Must be omitted for future grants.",
 			},
 
 			"database": &schema.Schema{
@@ -28,10 +31,17 @@ func resourceSchemaGrant() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"future": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Grant the privileges on schemas created in the database in the future",
+			},
+
 			"privileges": &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
-				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -45,36 +55,32 @@ func resourceSchemaGrant() *schema.Resource {
 			"grant_option": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
 				Default:  false,
 			},
+
+			"revoke_all_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revoke all privileges on the object from the role on destroy, instead of only the managed ones",
+			},
 		},
 	}
 }
 
 func createSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	txn, err := db.Begin()
-
-	defer func() {
-		_ = txn.Rollback()
-	}()
 
 	var (
 		schemaName   = d.Get("schema").(string)
 		databaseName = d.Get("database").(string)
 		role         = d.Get("role").(string)
+		future       = d.Get("future").(bool)
 	)
 
-	stmtSQL := fmt.Sprintf("USE DATABASE \"%s\"", databaseName)
-	_, err = txn.Exec(stmtSQL)
-	if err != nil {
-		return err
-	}
-
-	stmtSQL = fmt.Sprintf("GRANT %s ON %s TO ROLE \"%s\"",
+	stmtSQL := fmt.Sprintf("GRANT %s ON %s TO ROLE \"%s\"",
 		privilegesSetToString(d.Get("privileges").(*schema.Set)),
-		generateRecipientSchemaString(schemaName, databaseName),
+		generateRecipientSchemaString(schemaName, databaseName, future),
 		role)
 
 	if d.Get("grant_option").(bool) {
@@ -82,17 +88,12 @@ func createSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Println("Executing statement:", stmtSQL)
-	_, err = txn.Exec(stmtSQL)
+	_, err := db.Exec(stmtSQL)
 	if err != nil {
 		return err
 	}
 
-	err = txn.Commit()
-	if err != nil {
-		return err
-	}
-
-	id := generateSchemaGrantID(databaseName, schemaName, role)
+	id := generateSchemaGrantID(databaseName, schemaName, role, future)
 	d.SetId(id)
 
 	return readSchemaGrant(d, meta)
@@ -100,9 +101,17 @@ func createSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 
 func readSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	databaseName, schemaName, role := getParamsFromSchemaGrantID(d.Id())
+	databaseName, schemaName, role, future, err := getParamsFromSchemaGrantID(d.Id())
+	if err != nil {
+		return err
+	}
 
-	stmtSQL := fmt.Sprintf("SHOW GRANTS TO ROLE \"%s\"", role)
+	var stmtSQL string
+	if future {
+		stmtSQL = fmt.Sprintf("SHOW FUTURE GRANTS IN DATABASE %s", qualifiedName(databaseName))
+	} else {
+		stmtSQL = fmt.Sprintf("SHOW GRANTS TO ROLE \"%s\"", role)
+	}
 
 	log.Println("Executing statement:", stmtSQL)
 	rows, err := db.Query(stmtSQL)
@@ -125,12 +134,26 @@ func readSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 		objectGrantOption bool
 	)
 
+	managed := d.Get("privileges").(*schema.Set)
+
 	for rows.Next() {
+		if future {
+			if err := rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption); err != nil {
+				return err
+			}
+
+			if grantedOn == "SCHEMA" && grantedTo == "ROLE" && granteeName == role && managedPrivilege(privilege, schemaPrivileges, managed) {
+				privileges = append(privileges, privilege)
+				objectGrantOption = grantOption
+			}
+			continue
+		}
+
 		if err := rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption, &grantedBy); err != nil {
 			return err
 		}
 
-		if grantedOn == "SCHEMA" && validateSchemaName(name, databaseName, schemaName) {
+		if grantedOn == "SCHEMA" && validateSchemaName(name, databaseName, schemaName) && managedPrivilege(privilege, schemaPrivileges, managed) {
 			privileges = append(privileges, privilege)
 			objectGrantOption = grantOption
 		}
@@ -139,41 +162,46 @@ func readSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 	if len(privileges) > 0 {
 		d.Set("schema", schemaName)
 		d.Set("database", databaseName)
+		d.Set("future", future)
 		d.Set("role", role)
-		d.Set("privileges", schema.NewSet(schema.HashString, privileges))
+		d.Set("privileges", privilegesForState(privileges, schemaPrivileges, managed))
 		d.Set("grant_option", objectGrantOption)
 		return nil
 	}
 
-	return fmt.Errorf("The grant of role %s on %s does not exist.", role, generateRecipientSchemaString(schemaName, databaseName))
+	return fmt.Errorf("The grant of role %s on %s does not exist.", role, generateRecipientSchemaString(schemaName, databaseName, future))
 }
 
-func deleteSchemaGrant(d *schema.ResourceData, meta interface{}) error {
+func updateSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	txn, err := db.Begin()
-	databaseName, schemaName, role := getParamsFromSchemaGrantID(d.Id())
-
-	defer func() {
-		_ = txn.Rollback()
-	}()
-
-	stmtSQL := fmt.Sprintf("USE DATABASE \"%s\"", databaseName)
-	_, err = txn.Exec(stmtSQL)
+	databaseName, schemaName, role, future, err := getParamsFromSchemaGrantID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	stmtSQL = fmt.Sprintf("REVOKE ALL PRIVILEGES ON %s FROM ROLE \"%s\"",
-		generateRecipientSchemaString(schemaName, databaseName),
-		role)
+	on := generateRecipientSchemaString(schemaName, databaseName, future)
 
-	log.Println("Executing statement:", stmtSQL)
-	_, err = txn.Exec(stmtSQL)
+	for _, stmtSQL := range grantUpdateStatements(d, on, role) {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readSchemaGrant(d, meta)
+}
+
+func deleteSchemaGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	databaseName, schemaName, role, future, err := getParamsFromSchemaGrantID(d.Id())
 	if err != nil {
-		return nil
+		return err
 	}
 
-	err = txn.Commit()
+	stmtSQL := revokeStatement(d, generateRecipientSchemaString(schemaName, databaseName, future), role)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err = db.Exec(stmtSQL)
 	if err == nil {
 		d.SetId("")
 	}
@@ -181,6 +209,27 @@ func deleteSchemaGrant(d *schema.ResourceData, meta interface{}) error {
 	return err
 }
 
+// customizeSchemaGrantDiff validates privileges and checks that the grant
+// targets either a schema or future schemas, but not both.
+func customizeSchemaGrantDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("schema") {
+		schemaName := d.Get("schema").(string)
+		future := d.Get("future").(bool)
+		if schemaName == "" && !future {
+			return fmt.Errorf("schema must be set unless future is true")
+		}
+		if schemaName != "" && future {
+			return fmt.Errorf("schema cannot be set for future grants")
+		}
+	}
+
+	if !d.NewValueKnown("privileges") {
+		return nil
+	}
+
+	return validatePrivileges("SCHEMA", schemaPrivileges, d.Get("privileges").(*schema.Set))
+}
+
 func validateSchemaName(nameToValidate, databaseName, schemaName string) bool {
 	databaseToValidate := strings.Split(nameToValidate, ".")[0]
 	schemaToValidate := strings.Split(nameToValidate, ".")[1]
@@ -191,18 +240,30 @@ func validateSchemaName(nameToValidate, databaseName, schemaName string) bool {
 	return databaseToValidate == databaseName && schemaToValidate == schemaName
 }
 
-func generateRecipientSchemaString(schema, database string) string {
+func generateRecipientSchemaString(schema, database string, future bool) string {
+	if future {
+		return fmt.Sprintf("FUTURE SCHEMAS IN DATABASE %s", qualifiedName(database))
+	}
 	if schema == "ALL" {
-		return fmt.Sprintf("ALL SCHEMAS IN DATABASE \"%s\"", database)
+		return fmt.Sprintf("ALL SCHEMAS IN DATABASE %s", qualifiedName(database))
 	}
-	return fmt.Sprintf("SCHEMA \"%s\"", schema)
+	return fmt.Sprintf("SCHEMA %s", qualifiedName(database, schema))
 }
 
-func generateSchemaGrantID(database, schema, role string) string {
-	return fmt.Sprintf("%s-%s-%s", database, schema, role)
+func generateSchemaGrantID(database, schema, role string, future bool) string {
+	return idFromParams(database, schema, role, strconv.FormatBool(future))
 }
 
-func getParamsFromSchemaGrantID(id string) (database, schema, role string) {
-	params := strings.Split(id, "-")
-	return params[0], params[1], params[2]
+// getParamsFromSchemaGrantID parses the current IDs as well as the original
+// three-part IDs, which predate future grants.
+func getParamsFromSchemaGrantID(id string) (database, schema, role string, future bool, err error) {
+	params, err := paramsFromID(id, 4)
+	if err != nil {
+		if params, err = paramsFromID(id, 3); err != nil {
+			return "", "", "", false, err
+		}
+		return params[0], params[1], params[2], false, nil
+	}
+	future, _ = strconv.ParseBool(params[3])
+	return params[0], params[1], params[2], future, nil
 }
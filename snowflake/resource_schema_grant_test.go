@@ -27,6 +27,6 @@ func TestAccSchemaGrantSnowflake(t *testing.T) {
 var testSnowflakeSchemaGrantConfig = `resource "snowflake_schema_grant" "foo" {
 	schema = "test_schema"
 	database = "test_database"
-	priviliges = ["privilege1"]
+	priviliges = ["USAGE"]
 	role = "test_role"
 }`
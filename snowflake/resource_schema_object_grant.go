@@ -7,20 +7,24 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourceSchemaObjectGrant() *schema.Resource {
 	return &schema.Resource{
-		Create: createSchemaObjectGrant,
-		Update: nil,
-		Read:   readSchemaObjectGrant,
-		Delete: deleteSchemaObjectGrant,
+		Create:        createSchemaObjectGrant,
+		Update:        updateSchemaObjectGrant,
+		Read:          readSchemaObjectGrant,
+		Delete:        deleteSchemaObjectGrant,
+		CustomizeDiff: customizeSchemaObjectGrantDiff,
 
 		Schema: map[string]*schema.Schema{
 			"object_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(objectTypes(schemaObjectPrivileges), true),
+				DiffSuppressFunc: suppressCaseDiff,
 			},
 
 			"object_name": &schema.Schema{
@@ -36,9 +40,10 @@ func resourceSchemaObjectGrant() *schema.Resource {
 			},
 
 			"schema": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Schema of the objects. When omitted, the grant applies to all or future objects in the database.",
 			},
 
 			"future": &schema.Schema{
@@ -51,7 +56,6 @@ func resourceSchemaObjectGrant() *schema.Resource {
 			"privileges": &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
-				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
@@ -65,45 +69,34 @@ func resourceSchemaObjectGrant() *schema.Resource {
 			"grant_option": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
 				Default:  false,
 			},
+
+			"revoke_all_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revoke all privileges on the object from the role on destroy, instead of only the managed ones",
+			},
 		},
 	}
 }
 
 func createSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	txn, err := db.Begin()
-
-	defer func() {
-		_ = txn.Rollback()
-	}()
 
 	var (
 		objectName   = d.Get("object_name").(string)
-		objectType   = d.Get("object_type").(string)
+		objectType   = strings.ToUpper(d.Get("object_type").(string))
 		databaseName = d.Get("database").(string)
 		schemaName   = d.Get("schema").(string)
 		future       = d.Get("future").(bool)
 		role         = d.Get("role").(string)
 	)
 
-	stmtSQL := fmt.Sprintf("USE DATABASE \"%s\"", databaseName)
-	_, err = txn.Exec(stmtSQL)
-	if err != nil {
-		return err
-	}
-
-	stmtSQL = fmt.Sprintf("USE SCHEMA \"%s\"", schemaName)
-	_, err = txn.Exec(stmtSQL)
-	if err != nil {
-		return err
-	}
-
-	stmtSQL = fmt.Sprintf("GRANT %s ON %s TO ROLE \"%s\"",
+	stmtSQL := fmt.Sprintf("GRANT %s ON %s TO ROLE \"%s\"",
 		privilegesSetToString(d.Get("privileges").(*schema.Set)),
-		generateRecipientSchemaObjectString(objectType, objectName, schemaName, future),
+		generateRecipientSchemaObjectString(objectType, objectName, databaseName, schemaName, future),
 		role)
 
 	if d.Get("grant_option").(bool) {
@@ -111,12 +104,7 @@ func createSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Println("Executing statement:", stmtSQL)
-	_, err = txn.Exec(stmtSQL)
-	if err != nil {
-		return err
-	}
-
-	err = txn.Commit()
+	_, err := db.Exec(stmtSQL)
 	if err != nil {
 		return err
 	}
@@ -129,21 +117,7 @@ func createSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 
 func readSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	txn, err := db.Begin()
-	objectType, objectName, databaseName, schemaName, role, future := getParamsFromSchemaObjectGrantID(d.Id())
-
-	defer func() {
-		_ = txn.Rollback()
-	}()
-
-	stmtSQL := fmt.Sprintf("USE DATABASE \"%s\"", databaseName)
-	_, err = txn.Exec(stmtSQL)
-	if err != nil {
-		return err
-	}
-
-	stmtSQL = fmt.Sprintf("USE SCHEMA \"%s\"", schemaName)
-	_, err = txn.Exec(stmtSQL)
+	objectType, objectName, databaseName, schemaName, role, future, err := getParamsFromSchemaObjectGrantID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -161,11 +135,19 @@ func readSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 		objectGrantOption bool
 	)
 
+	managed := d.Get("privileges").(*schema.Set)
+	valid := schemaObjectPrivileges[objectType]
+
 	if future {
-		stmtSQL = fmt.Sprintf("SHOW FUTURE GRANTS IN SCHEMA \"%s\"", schemaName)
+		var stmtSQL string
+		if schemaName != "" {
+			stmtSQL = fmt.Sprintf("SHOW FUTURE GRANTS IN SCHEMA %s", qualifiedName(databaseName, schemaName))
+		} else {
+			stmtSQL = fmt.Sprintf("SHOW FUTURE GRANTS IN DATABASE %s", qualifiedName(databaseName))
+		}
 
 		log.Println("Executing statement:", stmtSQL)
-		rows, err := txn.Query(stmtSQL)
+		rows, err := db.Query(stmtSQL)
 		if err != nil {
 			return err
 		}
@@ -177,16 +159,16 @@ func readSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 				return err
 			}
 
-			if grantedTo == "ROLE" && granteeName == role && grantedOn == objectType {
+			if grantedTo == "ROLE" && granteeName == role && isGrantedOn(grantedOn, objectType) && managedPrivilege(privilege, valid, managed) {
 				privileges = append(privileges, privilege)
 				objectGrantOption = grantOption
 			}
 		}
 	} else {
-		stmtSQL = fmt.Sprintf("SHOW GRANTS TO ROLE \"%s\"", role)
+		stmtSQL := fmt.Sprintf("SHOW GRANTS TO ROLE \"%s\"", role)
 
 		log.Println("Executing statement:", stmtSQL)
-		rows, err := txn.Query(stmtSQL)
+		rows, err := db.Query(stmtSQL)
 		if err != nil {
 			return err
 		}
@@ -198,7 +180,7 @@ func readSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 				return err
 			}
 
-			if grantedOn == objectType && validateSchemaObjectName(name, databaseName, schemaName, objectName) {
+			if isGrantedOn(grantedOn, objectType) && validateSchemaObjectName(name, databaseName, schemaName, objectName) && managedPrivilege(privilege, valid, managed) {
 				privileges = append(privileges, privilege)
 				objectGrantOption = grantOption
 			}
@@ -212,46 +194,44 @@ func readSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 		d.Set("schema", schemaName)
 		d.Set("future", future)
 		d.Set("role", role)
-		d.Set("privileges", schema.NewSet(schema.HashString, privileges))
+		d.Set("privileges", privilegesForState(privileges, valid, managed))
 		d.Set("grant_option", objectGrantOption)
 		return nil
 	}
 
-	return fmt.Errorf("The grant of role %s on %s does not exist.", role, generateRecipientSchemaObjectString(objectType, objectName, schemaName, future))
+	return fmt.Errorf("The grant of role %s on %s does not exist.", role, generateRecipientSchemaObjectString(objectType, objectName, databaseName, schemaName, future))
 }
 
-func deleteSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
+func updateSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
 	db := meta.(*providerConfiguration).DB
-	txn, err := db.Begin()
-	objectType, objectName, databaseName, schemaName, role, future := getParamsFromSchemaObjectGrantID(d.Id())
-
-	defer func() {
-		_ = txn.Rollback()
-	}()
-
-	stmtSQL := fmt.Sprintf("USE DATABASE \"%s\"", databaseName)
-	_, err = txn.Exec(stmtSQL)
+	objectType, objectName, databaseName, schemaName, role, future, err := getParamsFromSchemaObjectGrantID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	stmtSQL = fmt.Sprintf("USE SCHEMA \"%s\"", schemaName)
-	_, err = txn.Exec(stmtSQL)
-	if err != nil {
-		return err
+	on := generateRecipientSchemaObjectString(objectType, objectName, databaseName, schemaName, future)
+
+	for _, stmtSQL := range grantUpdateStatements(d, on, role) {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
 	}
 
-	stmtSQL = fmt.Sprintf("REVOKE ALL PRIVILEGES ON %s FROM ROLE \"%s\"",
-		generateRecipientSchemaObjectString(objectType, objectName, schemaName, future),
-		role)
+	return readSchemaObjectGrant(d, meta)
+}
 
-	log.Println("Executing statement:", stmtSQL)
-	_, err = txn.Exec(stmtSQL)
+func deleteSchemaObjectGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, databaseName, schemaName, role, future, err := getParamsFromSchemaObjectGrantID(d.Id())
 	if err != nil {
-		return nil
+		return err
 	}
 
-	err = txn.Commit()
+	stmtSQL := revokeStatement(d, generateRecipientSchemaObjectString(objectType, objectName, databaseName, schemaName, future), role)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err = db.Exec(stmtSQL)
 	if err == nil {
 		d.SetId("")
 	}
@@ -264,30 +244,59 @@ func validateSchemaObjectName(nameToValidate, databaseName, schemaName, objectNa
 	schemaToValidate := strings.Split(nameToValidate, ".")[1]
 	objectNameToValidate := strings.Split(nameToValidate, ".")[2]
 
+	if len(schemaName) == 0 {
+		return databaseToValidate == databaseName
+	}
 	if len(objectName) == 0 {
 		return databaseToValidate == databaseName && schemaToValidate == schemaName
 	}
 	return databaseToValidate == databaseName && schemaToValidate == schemaName && objectNameToValidate == objectName
 }
 
-func generateRecipientSchemaObjectString(objectType, objectName, schema string, future bool) string {
+func generateRecipientSchemaObjectString(objectType, objectName, database, schema string, future bool) string {
 	if future {
-		return fmt.Sprintf("FUTURE %sS IN SCHEMA\"%s\"", objectType, schema)
+		if len(schema) == 0 {
+			return fmt.Sprintf("FUTURE %sS IN DATABASE %s", objectType, qualifiedName(database))
+		}
+		return fmt.Sprintf("FUTURE %sS IN SCHEMA %s", objectType, qualifiedName(database, schema))
 	}
 
 	if len(objectName) > 0 {
-		return fmt.Sprintf("%s \"%s\"", objectType, objectName)
+		return fmt.Sprintf("%s %s", objectType, qualifiedName(database, schema, objectName))
 	}
 
-	return fmt.Sprintf("ALL %sS IN SCHEMA \"%s\"", objectType, schema)
+	if len(schema) == 0 {
+		return fmt.Sprintf("ALL %sS IN DATABASE %s", objectType, qualifiedName(database))
+	}
+	return fmt.Sprintf("ALL %sS IN SCHEMA %s", objectType, qualifiedName(database, schema))
+}
+
+// customizeSchemaObjectGrantDiff validates privileges against the object type
+// and rejects a single object name without the schema it lives in.
+func customizeSchemaObjectGrantDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeObjectGrantDiff(schemaObjectPrivileges)(d, meta); err != nil {
+		return err
+	}
+
+	if d.Get("object_name").(string) != "" && d.Get("schema").(string) == "" && d.NewValueKnown("schema") {
+		return fmt.Errorf("schema must be set when granting on a single object")
+	}
+	if d.Get("object_name").(string) != "" && d.Get("future").(bool) {
+		return fmt.Errorf("object_name cannot be set for future grants")
+	}
+
+	return nil
 }
 
 func generateSchemaObjectGrantID(objectType, objectName, database, schema, role string, future bool) string {
-	return fmt.Sprintf("%s-%s-%s-%s-%s-%s", objectType, objectName, strconv.FormatBool(future), database, schema, role)
+	return idFromParams(objectType, objectName, strconv.FormatBool(future), database, schema, role)
 }
 
-func getParamsFromSchemaObjectGrantID(id string) (objectType, objectName, database, schema, role string, future bool) {
-	params := strings.Split(id, "-")
+func getParamsFromSchemaObjectGrantID(id string) (objectType, objectName, database, schema, role string, future bool, err error) {
+	params, err := paramsFromID(id, 6)
+	if err != nil {
+		return "", "", "", "", "", false, err
+	}
 	future, _ = strconv.ParseBool(params[2])
-	return params[0], params[1], params[3], params[4], params[5], future
+	return strings.ToUpper(params[0]), params[1], params[3], params[4], params[5], future, nil
 }
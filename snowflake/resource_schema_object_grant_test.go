@@ -32,6 +32,6 @@ var testSnowflakeSchemaObjectGrantConfig = `resource "snowflake_schema_object_gr
 	object_name = "SAMPLE_TABLE"
 	database = "MASTER"
 	schema = "SAMPLE_SCHEMA""
-	priviliges = ["privilege1"]
+	priviliges = ["SELECT"]
 	role = "SAMPLE_ROLE"
 }`
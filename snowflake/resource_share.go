@@ -0,0 +1,236 @@
+package snowflake
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceShare() *schema.Resource {
+	return &schema.Resource{
+		Create: createShare,
+		Read:   readShare,
+		Update: updateShare,
+		Delete: deleteShare,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the share",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"accounts": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Consumer accounts the share is shared with, as managed by snowflake_share_accounts",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"secure_objects_only": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether only secure views and functions can be added to the share",
+			},
+
+			"database_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The database granted to the share",
+			},
+
+			"objects": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The objects in the share, as reported by DESCRIBE SHARE",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func createShare(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	name := d.Get("name").(string)
+
+	stmtSQL := fmt.Sprintf("CREATE SHARE \"%s\"", name)
+
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+
+	if !d.Get("secure_objects_only").(bool) {
+		if err := alterShare(meta, fmt.Sprintf("ALTER SHARE \"%s\" SET SECURE_OBJECTS_ONLY = FALSE", name)); err != nil {
+			return err
+		}
+	}
+
+	return readShare(d, meta)
+}
+
+func readShare(d *schema.ResourceData, meta interface{}) error {
+	name := d.Id()
+
+	row, err := showOutboundShare(meta, name)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		log.Printf("[WARN] share %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("comment", row["comment"].String)
+	d.Set("accounts", shareAccounts(row))
+	d.Set("database_name", row["database_name"].String)
+	if secureObjectsOnly, ok := row["secure_objects_only"]; ok && secureObjectsOnly.Valid {
+		d.Set("secure_objects_only", strings.ToLower(secureObjectsOnly.String) == "true")
+	}
+
+	return readShareObjects(d, meta)
+}
+
+// showOutboundShare returns the row of SHOW SHARES for the outbound share
+// called name, or nil if there is none.
+func showOutboundShare(meta interface{}, name string) (map[string]sql.NullString, error) {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("SHOW SHARES LIKE '%s'", name)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		// The name is reported qualified with the account, and SHOW SHARES
+		// also lists inbound shares from other accounts.
+		qualified := strings.Split(row["name"].String, ".")
+		if row["kind"].String == "OUTBOUND" && qualified[len(qualified)-1] == name {
+			return row, nil
+		}
+	}
+
+	return nil, rows.Err()
+}
+
+// shareAccounts returns the consumer accounts listed in the "to" column of
+// SHOW SHARES.
+func shareAccounts(row map[string]sql.NullString) *schema.Set {
+	var accounts []interface{}
+	for _, account := range strings.Split(row["to"].String, ",") {
+		if account = strings.TrimSpace(account); account != "" {
+			accounts = append(accounts, account)
+		}
+	}
+	return schema.NewSet(schema.HashString, accounts)
+}
+
+// readShareObjects lists the objects in the share as "KIND NAME".
+func readShareObjects(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("DESCRIBE SHARE \"%s\"", d.Id())
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var objects []string
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, fmt.Sprintf("%s %s", row["kind"].String, row["name"].String))
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("objects", objects)
+	return nil
+}
+
+func updateShare(d *schema.ResourceData, meta interface{}) error {
+	name := d.Id()
+
+	if d.HasChange("comment") {
+		var stmtSQL string
+		if comment := d.Get("comment").(string); comment == "" {
+			stmtSQL = fmt.Sprintf("ALTER SHARE \"%s\" UNSET COMMENT", name)
+		} else {
+			stmtSQL = fmt.Sprintf("ALTER SHARE \"%s\" SET COMMENT = %s", name, quoteString(comment))
+		}
+		if err := alterShare(meta, stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("secure_objects_only") {
+		stmtSQL := fmt.Sprintf("ALTER SHARE \"%s\" SET SECURE_OBJECTS_ONLY = %t", name, d.Get("secure_objects_only").(bool))
+		if err := alterShare(meta, stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readShare(d, meta)
+}
+
+func deleteShare(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("DROP SHARE \"%s\"", d.Id())
+
+	log.Println("Executing statement:", stmtSQL)
+
+	_, err := db.Exec(stmtSQL)
+	if err == nil {
+		d.SetId("")
+	}
+	return err
+}
+
+func alterShare(meta interface{}, stmtSQL string) error {
+	db := meta.(*providerConfiguration).DB
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err := db.Exec(stmtSQL)
+	return err
+}
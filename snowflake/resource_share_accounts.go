@@ -0,0 +1,105 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceShareAccounts manages the consumer accounts of a share. It is kept
+// apart from the share because Snowflake only accepts accounts once a database
+// has been granted to the share, so it has to be created after the
+// snowflake_share_grant of that database.
+func resourceShareAccounts() *schema.Resource {
+	return &schema.Resource{
+		Create: createShareAccounts,
+		Read:   readShareAccounts,
+		Update: updateShareAccounts,
+		Delete: deleteShareAccounts,
+
+		Schema: map[string]*schema.Schema{
+			"share": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the share",
+			},
+
+			"accounts": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Consumer accounts the share is shared with. Any other account is removed.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+	}
+}
+
+func createShareAccounts(d *schema.ResourceData, meta interface{}) error {
+	share := d.Get("share").(string)
+
+	// SET replaces any accounts added outside of terraform.
+	stmtSQL := fmt.Sprintf("ALTER SHARE \"%s\" SET ACCOUNTS = %s", share, setToString(d.Get("accounts").(*schema.Set)))
+	if err := alterShare(meta, stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(share)
+
+	return readShareAccounts(d, meta)
+}
+
+func readShareAccounts(d *schema.ResourceData, meta interface{}) error {
+	share := d.Id()
+
+	row, err := showOutboundShare(meta, share)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		log.Printf("[WARN] share %s not found, removing from state", share)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("share", share)
+	d.Set("accounts", shareAccounts(row))
+	return nil
+}
+
+func updateShareAccounts(d *schema.ResourceData, meta interface{}) error {
+	share := d.Id()
+
+	if d.HasChange("accounts") {
+		o, n := d.GetChange("accounts")
+		oldAccounts := o.(*schema.Set)
+		newAccounts := n.(*schema.Set)
+
+		if removed := oldAccounts.Difference(newAccounts); removed.Len() > 0 {
+			if err := alterShare(meta, fmt.Sprintf("ALTER SHARE \"%s\" REMOVE ACCOUNTS = %s", share, setToString(removed))); err != nil {
+				return err
+			}
+		}
+
+		if added := newAccounts.Difference(oldAccounts); added.Len() > 0 {
+			if err := alterShare(meta, fmt.Sprintf("ALTER SHARE \"%s\" ADD ACCOUNTS = %s", share, setToString(added))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return readShareAccounts(d, meta)
+}
+
+func deleteShareAccounts(d *schema.ResourceData, meta interface{}) error {
+	if accounts := d.Get("accounts").(*schema.Set); accounts.Len() > 0 {
+		if err := alterShare(meta, fmt.Sprintf("ALTER SHARE \"%s\" REMOVE ACCOUNTS = %s", d.Id(), setToString(accounts))); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
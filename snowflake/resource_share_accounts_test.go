@@ -0,0 +1,39 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccShareAccountsSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeShareAccountsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_share_accounts.foo", "share", "tf-test-share"),
+					resource.TestCheckResourceAttr("snowflake_share_accounts.foo", "accounts.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeShareAccountsConfig = `resource "snowflake_share" "foo" {
+	name = "tf-test-share"
+}
+
+resource "snowflake_share_grant" "foo" {
+	object_type = "DATABASE"
+	database = "MASTER"
+	privileges = ["USAGE"]
+	share = "${snowflake_share.foo.name}"
+}
+
+resource "snowflake_share_accounts" "foo" {
+	share = "${snowflake_share.foo.name}"
+	accounts = ["PARTNER_ORG.PARTNER_ACCOUNT"]
+	depends_on = ["snowflake_share_grant.foo"]
+}`
@@ -0,0 +1,245 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceShareGrant() *schema.Resource {
+	return &schema.Resource{
+		Create:        createShareGrant,
+		Update:        updateShareGrant,
+		Read:          readShareGrant,
+		Delete:        deleteShareGrant,
+		CustomizeDiff: customizeShareGrantDiff,
+
+		Schema: map[string]*schema.Schema{
+			"object_type": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(objectTypes(sharePrivileges), true),
+				DiffSuppressFunc: suppressCaseDiff,
+			},
+
+			"object_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the object in the schema. When omitted, the grant applies to all objects of the type in the schema.",
+			},
+
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Schema of the objects. Not used when object_type is DATABASE.",
+			},
+
+			"privileges": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"share": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The share to which the privileges are granted",
+			},
+		},
+	}
+}
+
+func createShareGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	var (
+		objectType   = strings.ToUpper(d.Get("object_type").(string))
+		objectName   = d.Get("object_name").(string)
+		databaseName = d.Get("database").(string)
+		schemaName   = d.Get("schema").(string)
+		share        = d.Get("share").(string)
+	)
+
+	stmtSQL := fmt.Sprintf("GRANT %s ON %s TO SHARE \"%s\"",
+		privilegesSetToString(d.Get("privileges").(*schema.Set)),
+		generateShareGrantTarget(objectType, objectName, databaseName, schemaName),
+		share)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err := db.Exec(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(generateShareGrantID(objectType, objectName, databaseName, schemaName, share))
+
+	return readShareGrant(d, meta)
+}
+
+func readShareGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, databaseName, schemaName, share, err := getParamsFromShareGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW GRANTS TO SHARE \"%s\"", share)
+
+	log.Println("Executing statement:", stmtSQL)
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var (
+		createdOn   string
+		privilege   string
+		grantedOn   string
+		name        string
+		grantedTo   string
+		granteeName string
+		grantOption bool
+		grantedBy   string
+		privileges  []interface{}
+	)
+
+	managed := d.Get("privileges").(*schema.Set)
+	valid := sharePrivileges[objectType]
+
+	for rows.Next() {
+		if err := rows.Scan(&createdOn, &privilege, &grantedOn, &name, &grantedTo, &granteeName, &grantOption, &grantedBy); err != nil {
+			return err
+		}
+
+		if isGrantedOn(grantedOn, objectType) && validateShareGrantName(name, objectType, databaseName, schemaName, objectName) && managedPrivilege(privilege, valid, managed) {
+			privileges = append(privileges, privilege)
+		}
+	}
+
+	if len(privileges) > 0 {
+		d.Set("object_type", objectType)
+		d.Set("object_name", objectName)
+		d.Set("database", databaseName)
+		d.Set("schema", schemaName)
+		d.Set("share", share)
+		d.Set("privileges", privilegesForState(privileges, valid, managed))
+		return nil
+	}
+
+	return fmt.Errorf("The grant to share %s on %s does not exist.", share, generateShareGrantTarget(objectType, objectName, databaseName, schemaName))
+}
+
+func updateShareGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, databaseName, schemaName, share, err := getParamsFromShareGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	o, n := d.GetChange("privileges")
+	statements := privilegeChangeStatements(o.(*schema.Set), n.(*schema.Set), false, false,
+		generateShareGrantTarget(objectType, objectName, databaseName, schemaName),
+		fmt.Sprintf("SHARE \"%s\"", share))
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readShareGrant(d, meta)
+}
+
+func deleteShareGrant(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+	objectType, objectName, databaseName, schemaName, share, err := getParamsFromShareGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("REVOKE %s ON %s FROM SHARE \"%s\"",
+		privilegesSetToString(d.Get("privileges").(*schema.Set)),
+		generateShareGrantTarget(objectType, objectName, databaseName, schemaName),
+		share)
+
+	log.Println("Executing statement:", stmtSQL)
+	_, err = db.Exec(stmtSQL)
+	if err == nil {
+		d.SetId("")
+	}
+	return err
+}
+
+// customizeShareGrantDiff validates privileges against the object type and
+// checks that a schema is given for everything below the database.
+func customizeShareGrantDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeObjectGrantDiff(sharePrivileges)(d, meta); err != nil {
+		return err
+	}
+
+	objectType := strings.ToUpper(d.Get("object_type").(string))
+	if objectType != "DATABASE" && d.Get("schema").(string) == "" && d.NewValueKnown("schema") {
+		return fmt.Errorf("schema must be set when granting on %s to a share", objectType)
+	}
+
+	return nil
+}
+
+func validateShareGrantName(nameToValidate, objectType, databaseName, schemaName, objectName string) bool {
+	parts := strings.Split(nameToValidate, ".")
+
+	switch objectType {
+	case "DATABASE":
+		return parts[0] == databaseName
+	case "SCHEMA":
+		return len(parts) > 1 && parts[0] == databaseName && parts[1] == schemaName
+	}
+
+	if len(parts) < 3 || parts[0] != databaseName || parts[1] != schemaName {
+		return false
+	}
+	return len(objectName) == 0 || parts[2] == objectName
+}
+
+func generateShareGrantTarget(objectType, objectName, database, schema string) string {
+	switch objectType {
+	case "DATABASE":
+		return fmt.Sprintf("DATABASE %s", qualifiedName(database))
+	case "SCHEMA":
+		return fmt.Sprintf("SCHEMA %s", qualifiedName(database, schema))
+	}
+
+	if len(objectName) > 0 {
+		return fmt.Sprintf("%s %s", objectType, qualifiedName(database, schema, objectName))
+	}
+	return fmt.Sprintf("ALL %sS IN SCHEMA %s", objectType, qualifiedName(database, schema))
+}
+
+func generateShareGrantID(objectType, objectName, database, schema, share string) string {
+	return idFromParams(objectType, objectName, database, schema, share)
+}
+
+func getParamsFromShareGrantID(id string) (objectType, objectName, database, schema, share string, err error) {
+	params, err := paramsFromID(id, 5)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	return strings.ToUpper(params[0]), params[1], params[2], params[3], params[4], nil
+}
@@ -0,0 +1,33 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccShareGrantSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeShareGrantConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_share_grant.foo", "object_type", "TABLE"),
+					resource.TestCheckResourceAttr("snowflake_share_grant.foo", "object_name", "SAMPLE_TABLE"),
+					resource.TestCheckResourceAttr("snowflake_share_grant.foo", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("snowflake_share_grant.foo", "share", "test_share"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeShareGrantConfig = `resource "snowflake_share_grant" "foo" {
+	object_type = "TABLE"
+	object_name = "SAMPLE_TABLE"
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	privileges = ["SELECT"]
+	share = "test_share"
+}`
@@ -0,0 +1,28 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccShareSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeShareConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_share.foo", "name", "tf-test-share"),
+					resource.TestCheckResourceAttr("snowflake_share.foo", "comment", "test share"),
+					resource.TestCheckResourceAttr("snowflake_share.foo", "secure_objects_only", "true"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeShareConfig = `resource "snowflake_share" "foo" {
+	name = "tf-test-share"
+	comment = "test share"
+}`
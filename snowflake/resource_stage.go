@@ -0,0 +1,401 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceStage() *schema.Resource {
+	return &schema.Resource{
+		Create:        createStage,
+		Read:          readStage,
+		Update:        updateStage,
+		Delete:        deleteStage,
+		CustomizeDiff: customizeStageDiff,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Location of the files of an external stage, e.g. s3://bucket/path/. Internal stages have no URL.",
+			},
+
+			"storage_integration": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Storage integration used to access the URL",
+				ConflictsWith: []string{"credentials"},
+			},
+
+			"credentials": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Credentials for the URL, e.g. AWS_KEY_ID = '...' AWS_SECRET_KEY = '...'. Not read back.",
+				ConflictsWith: []string{"storage_integration"},
+			},
+
+			"encryption": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Encryption settings for the files, e.g. TYPE = 'AWS_SSE_KMS' KMS_KEY_ID = '...'. Not read back.",
+			},
+
+			"file_format": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Inline format options, e.g. TYPE = CSV FIELD_DELIMITER = '|', or the name of a file format",
+			},
+
+			"copy_options": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Copy options used when loading from the stage, e.g. ON_ERROR = CONTINUE",
+			},
+
+			"directory_enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the stage has a directory table",
+			},
+
+			"directory_auto_refresh": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the directory table of an external stage is refreshed from event notifications",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func createStage(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	stmtSQL := fmt.Sprintf("CREATE STAGE %s", qualifiedName(database, schemaName, name))
+
+	if v, ok := d.GetOk("url"); ok {
+		stmtSQL += fmt.Sprintf(" URL = %s", quoteString(v.(string)))
+	}
+	if v, ok := d.GetOk("storage_integration"); ok {
+		stmtSQL += fmt.Sprintf(" STORAGE_INTEGRATION = \"%s\"", v.(string))
+	}
+	if v, ok := d.GetOk("credentials"); ok {
+		stmtSQL += fmt.Sprintf(" CREDENTIALS = (%s)", v.(string))
+	}
+	if v, ok := d.GetOk("encryption"); ok {
+		stmtSQL += fmt.Sprintf(" ENCRYPTION = (%s)", v.(string))
+	}
+	if d.Get("directory_enabled").(bool) {
+		stmtSQL += fmt.Sprintf(" DIRECTORY = (ENABLE = TRUE AUTO_REFRESH = %t)", d.Get("directory_auto_refresh").(bool))
+	}
+	if v, ok := d.GetOk("file_format"); ok {
+		stmtSQL += fmt.Sprintf(" FILE_FORMAT = (%s)", stageFileFormat(v.(string)))
+	}
+	if v, ok := d.GetOk("copy_options"); ok {
+		stmtSQL += fmt.Sprintf(" COPY_OPTIONS = (%s)", v.(string))
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(stageIDFromParams(database, schemaName, name))
+
+	return readStage(d, meta)
+}
+
+func readStage(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromStageID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW STAGES LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("url", row["url"].String)
+		d.Set("storage_integration", row["storage_integration"].String)
+		d.Set("comment", row["comment"].String)
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] stage %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+		return nil
+	}
+
+	properties, err := describeStage(meta, qualifiedName(database, schemaName, name))
+	if err != nil {
+		return err
+	}
+
+	d.Set("directory_enabled", strings.ToLower(properties["DIRECTORY"]["ENABLE"]) == "true")
+	d.Set("directory_auto_refresh", strings.ToLower(properties["DIRECTORY"]["AUTO_REFRESH"]) == "true")
+
+	fileFormat := d.Get("file_format").(string)
+	if formatName, ok := properties["STAGE_FILE_FORMAT"]["FORMAT_NAME"]; ok && formatName != "" {
+		// The name may be reported qualified and quoted.
+		if !strings.EqualFold(strings.Replace(unquoteOption(fileFormat), "\"", "", -1), strings.Replace(unquoteOption(formatName), "\"", "", -1)) {
+			fileFormat = formatName
+		}
+	} else if fileFormat != "" {
+		fileFormat = stageOptionsWithDescribed(fileFormat, properties["STAGE_FILE_FORMAT"])
+	}
+	d.Set("file_format", fileFormat)
+
+	if copyOptions := d.Get("copy_options").(string); copyOptions != "" {
+		d.Set("copy_options", stageOptionsWithDescribed(copyOptions, properties["STAGE_COPY_OPTIONS"]))
+	}
+
+	return nil
+}
+
+// describeStage returns the properties listed by DESCRIBE STAGE, keyed by
+// parent property and property name.
+func describeStage(meta interface{}, stage string) (map[string]map[string]string, error) {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("DESCRIBE STAGE %s", stage)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	properties := make(map[string]map[string]string)
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		parent := row["parent_property"].String
+		if properties[parent] == nil {
+			properties[parent] = make(map[string]string)
+		}
+		properties[parent][row["property"].String] = row["property_value"].String
+	}
+
+	return properties, rows.Err()
+}
+
+func updateStage(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromStageID(d.Id())
+	if err != nil {
+		return err
+	}
+	stage := qualifiedName(database, schemaName, name)
+
+	var statements []string
+
+	// An internal stage cannot become external or the other way round, see
+	// customizeStageDiff, so the URL only changes between two locations.
+	if d.HasChange("url") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET URL = %s", stage, quoteString(d.Get("url").(string))))
+	}
+	if d.HasChange("storage_integration") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET STORAGE_INTEGRATION = \"%s\"", stage, d.Get("storage_integration").(string)))
+	}
+	if d.HasChange("credentials") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET CREDENTIALS = (%s)", stage, d.Get("credentials").(string)))
+	}
+	if d.HasChange("encryption") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET ENCRYPTION = (%s)", stage, d.Get("encryption").(string)))
+	}
+	if d.HasChange("file_format") {
+		// Removing the file format resets the stage to the default format.
+		fileFormat := "TYPE = CSV"
+		if v := d.Get("file_format").(string); v != "" {
+			fileFormat = stageFileFormat(v)
+		}
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET FILE_FORMAT = (%s)", stage, fileFormat))
+	}
+	if d.HasChange("copy_options") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET COPY_OPTIONS = (%s)", stage, d.Get("copy_options").(string)))
+	}
+	if d.HasChange("directory_enabled") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET DIRECTORY = (ENABLE = %t)", stage, d.Get("directory_enabled").(bool)))
+	}
+	if d.HasChange("comment") {
+		statements = append(statements, fmt.Sprintf("ALTER STAGE %s SET COMMENT = %s", stage, quoteString(d.Get("comment").(string))))
+	}
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readStage(d, meta)
+}
+
+func deleteStage(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromStageID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP STAGE %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeStageDiff recreates the stage when it switches between internal and
+// external, or when its storage integration is removed, as Snowflake cannot
+// make either change in place.
+func customizeStageDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if o, n := d.GetChange("url"); d.NewValueKnown("url") && (o.(string) == "") != (n.(string) == "") {
+		return d.ForceNew("url")
+	}
+	if o, n := d.GetChange("storage_integration"); o.(string) != "" && n.(string) == "" {
+		return d.ForceNew("storage_integration")
+	}
+	return nil
+}
+
+// stageOptionRegexp matches one KEY = value option, where the value is a
+// quoted string, a parenthesised list or a bare word.
+var stageOptionRegexp = regexp.MustCompile(`(\w+)\s*=\s*('(?:[^'\\]|\\.)*'|\([^)]*\)|[^\s]+)`)
+
+// stageFileFormat returns the contents of FILE_FORMAT = (...). A value
+// without options is the name of a file format.
+func stageFileFormat(fileFormat string) string {
+	if stageOptionRegexp.MatchString(fileFormat) {
+		return fileFormat
+	}
+	return fmt.Sprintf("FORMAT_NAME = %s", quoteString(fileFormat))
+}
+
+// stageOptionsWithDescribed checks the configured options against the values
+// reported by DESCRIBE STAGE. It returns the configured string when they match,
+// so that its formatting is kept, and otherwise the options with the reported
+// values so that the drift shows up in the plan. Lists are not compared, as
+// DESCRIBE STAGE reports them in a different syntax.
+func stageOptionsWithDescribed(configured string, described map[string]string) string {
+	matches := stageOptionRegexp.FindAllStringSubmatch(configured, -1)
+
+	drifted := false
+	options := make([]string, 0, len(matches))
+	for _, match := range matches {
+		key, value := strings.ToUpper(match[1]), match[2]
+
+		if actual, ok := described[key]; ok && !strings.HasPrefix(value, "(") && !strings.EqualFold(unquoteOption(value), unquoteOption(actual)) {
+			drifted = true
+			if strings.HasPrefix(value, "'") {
+				value = quoteString(actual)
+			} else {
+				value = actual
+			}
+		}
+		options = append(options, fmt.Sprintf("%s = %s", key, value))
+	}
+
+	if !drifted {
+		return configured
+	}
+	return strings.Join(options, " ")
+}
+
+func unquoteOption(value string) string {
+	return strings.Trim(strings.TrimSpace(value), "'\"")
+}
+
+func paramsFromStageID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func stageIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
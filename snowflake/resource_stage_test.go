@@ -0,0 +1,33 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccStageSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeStageConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_stage.foo", "name", "test_stage"),
+					resource.TestCheckResourceAttr("snowflake_stage.foo", "file_format", "TYPE = CSV FIELD_DELIMITER = '|'"),
+					resource.TestCheckResourceAttr("snowflake_stage.foo", "copy_options", "ON_ERROR = CONTINUE"),
+					resource.TestCheckResourceAttr("snowflake_stage.foo", "comment", "test stage"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeStageConfig = `resource "snowflake_stage" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_stage"
+	file_format = "TYPE = CSV FIELD_DELIMITER = '|'"
+	copy_options = "ON_ERROR = CONTINUE"
+	comment = "test stage"
+}`
@@ -0,0 +1,430 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// streamSources are the attributes naming the object a stream is on.
+var streamSources = []string{"on_table", "on_view", "on_stage"}
+
+func resourceStream() *schema.Resource {
+	return &schema.Resource{
+		Create:        createStream,
+		Read:          readStream,
+		Update:        updateStream,
+		Delete:        deleteStream,
+		CustomizeDiff: customizeStreamDiff,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"on_table": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Fully qualified name of the table the stream records changes of, as database.schema.table",
+				ConflictsWith:    []string{"on_view", "on_stage"},
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+			},
+
+			"on_view": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Fully qualified name of the view the stream records changes of, as database.schema.view",
+				ConflictsWith:    []string{"on_table", "on_stage"},
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+			},
+
+			"on_stage": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Fully qualified name of the stage whose directory table the stream records changes of",
+				ConflictsWith:    []string{"on_table", "on_view"},
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+			},
+
+			"append_only": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the stream only records inserts on a table or view",
+			},
+
+			"insert_only": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether on_table is an external table, on which streams only record inserts",
+			},
+
+			"show_initial_rows": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the first read of the stream returns the rows present when it was created",
+			},
+
+			"at": streamTimeTravelSchema("Point in time, inclusive, from which the stream records changes", "before"),
+
+			"before": streamTimeTravelSchema("Point in time, exclusive, from which the stream records changes", "at"),
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"stale": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the offset of the stream is outside the data retention period of its source",
+			},
+
+			"stale_after": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the stream may become stale if it is not consumed",
+			},
+
+			"mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DEFAULT, APPEND_ONLY or INSERT_ONLY",
+			},
+		},
+	}
+}
+
+func streamTimeTravelSchema(description, conflictsWith string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		Description:   description,
+		ConflictsWith: []string{conflictsWith},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timestamp": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"offset": &schema.Schema{
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "Seconds before now, as a negative number",
+				},
+				"statement": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Query ID of a statement",
+				},
+				"stream": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of a stream whose current offset is used",
+				},
+			},
+		},
+	}
+}
+
+func createStream(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	stmtSQL := fmt.Sprintf("CREATE STREAM %s ON ", qualifiedName(database, schemaName, name))
+
+	switch {
+	case d.Get("on_stage").(string) != "":
+		stmtSQL += fmt.Sprintf("STAGE %s", qualifiedNameFromString(d.Get("on_stage").(string)))
+	case d.Get("on_view").(string) != "":
+		stmtSQL += fmt.Sprintf("VIEW %s", qualifiedNameFromString(d.Get("on_view").(string)))
+	case d.Get("insert_only").(bool):
+		stmtSQL += fmt.Sprintf("EXTERNAL TABLE %s", qualifiedNameFromString(d.Get("on_table").(string)))
+	default:
+		stmtSQL += fmt.Sprintf("TABLE %s", qualifiedNameFromString(d.Get("on_table").(string)))
+	}
+
+	for _, clause := range []string{"at", "before"} {
+		if v, ok := d.GetOk(clause); ok {
+			stmtSQL += fmt.Sprintf(" %s (%s)", strings.ToUpper(clause), streamTimeTravelValue(v.([]interface{})[0].(map[string]interface{})))
+		}
+	}
+
+	if d.Get("append_only").(bool) {
+		stmtSQL += " APPEND_ONLY = TRUE"
+	}
+	if d.Get("insert_only").(bool) {
+		stmtSQL += " INSERT_ONLY = TRUE"
+	}
+	if d.Get("show_initial_rows").(bool) {
+		stmtSQL += " SHOW_INITIAL_ROWS = TRUE"
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(streamIDFromParams(database, schemaName, name))
+
+	return readStream(d, meta)
+}
+
+func readStream(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromStreamID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW STREAMS LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("comment", row["comment"].String)
+		d.Set("stale", strings.ToLower(row["stale"].String) == "true")
+		d.Set("stale_after", row["stale_after"].String)
+		d.Set("mode", row["mode"].String)
+		d.Set("append_only", row["mode"].String == "APPEND_ONLY")
+		d.Set("insert_only", row["mode"].String == "INSERT_ONLY")
+
+		switch strings.ToUpper(row["source_type"].String) {
+		case "TABLE", "EXTERNAL TABLE":
+			d.Set("on_table", row["table_name"].String)
+		case "VIEW":
+			d.Set("on_view", row["table_name"].String)
+		case "STAGE":
+			d.Set("on_stage", row["table_name"].String)
+		}
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] stream %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func updateStream(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromStreamID(d.Id())
+	if err != nil {
+		return err
+	}
+	stream := qualifiedName(database, schemaName, name)
+
+	if d.HasChange("comment") {
+		stmtSQL := fmt.Sprintf("ALTER STREAM %s UNSET COMMENT", stream)
+		if comment := d.Get("comment").(string); comment != "" {
+			stmtSQL = fmt.Sprintf("ALTER STREAM %s SET COMMENT = %s", stream, quoteString(comment))
+		}
+
+		log.Println("Executing statement:", stmtSQL)
+
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readStream(d, meta)
+}
+
+func deleteStream(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromStreamID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP STREAM %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeStreamDiff checks that the stream is on exactly one object and that
+// the options apply to that kind of object.
+func customizeStreamDiff(d *schema.ResourceDiff, meta interface{}) error {
+	var sources []string
+	for _, source := range streamSources {
+		if !d.NewValueKnown(source) {
+			return nil
+		}
+		if d.Get(source).(string) != "" {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) != 1 {
+		return fmt.Errorf("exactly one of %s must be set", strings.Join(streamSources, ", "))
+	}
+
+	source := sources[0]
+	if parts := splitQualifiedName(d.Get(source).(string)); len(parts) != 3 {
+		return fmt.Errorf("%s must be fully qualified as database.schema.name, got %q", source, d.Get(source).(string))
+	}
+	if d.Get("append_only").(bool) && d.Get("insert_only").(bool) {
+		return fmt.Errorf("append_only and insert_only cannot both be set")
+	}
+	if d.Get("insert_only").(bool) && source != "on_table" {
+		return fmt.Errorf("insert_only only applies to streams on external tables, given as on_table")
+	}
+	if source == "on_stage" {
+		for _, option := range []string{"append_only", "show_initial_rows"} {
+			if d.Get(option).(bool) {
+				return fmt.Errorf("%s does not apply to streams on stages", option)
+			}
+		}
+		for _, clause := range []string{"at", "before"} {
+			if len(d.Get(clause).([]interface{})) > 0 {
+				return fmt.Errorf("%s does not apply to streams on stages", clause)
+			}
+		}
+	}
+
+	for _, clause := range []string{"at", "before"} {
+		list := d.Get(clause).([]interface{})
+		if len(list) == 0 || list[0] == nil {
+			continue
+		}
+
+		set := 0
+		for _, v := range list[0].(map[string]interface{}) {
+			if v != "" && v != 0 {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("%s must set exactly one of timestamp, offset, statement or stream", clause)
+		}
+	}
+
+	return nil
+}
+
+// streamTimeTravelValue returns the contents of an AT or BEFORE clause.
+func streamTimeTravelValue(m map[string]interface{}) string {
+	switch {
+	case m["timestamp"].(string) != "":
+		return fmt.Sprintf("TIMESTAMP => TO_TIMESTAMP_LTZ(%s)", quoteString(m["timestamp"].(string)))
+	case m["offset"].(int) != 0:
+		return fmt.Sprintf("OFFSET => %d", m["offset"].(int))
+	case m["statement"].(string) != "":
+		return fmt.Sprintf("STATEMENT => %s", quoteString(m["statement"].(string)))
+	}
+	return fmt.Sprintf("STREAM => %s", quoteString(m["stream"].(string)))
+}
+
+// splitQualifiedName splits a dotted name such as database.schema.table into
+// its parts. Dots inside double-quoted parts do not split, and the quotes are
+// removed.
+func splitQualifiedName(name string) []string {
+	var parts []string
+	var part strings.Builder
+	quoted := false
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c == '"' && quoted && i+1 < len(name) && name[i+1] == '"':
+			part.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(c)
+		}
+	}
+	return append(parts, part.String())
+}
+
+// qualifiedNameFromString quotes each part of a dotted name such as
+// database.schema.table.
+func qualifiedNameFromString(name string) string {
+	return qualifiedName(splitQualifiedName(name)...)
+}
+
+func suppressQualifiedNameDiff(k, old, new string, d *schema.ResourceData) bool {
+	return qualifiedNameFromString(old) == qualifiedNameFromString(new)
+}
+
+func paramsFromStreamID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func streamIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
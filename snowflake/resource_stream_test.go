@@ -0,0 +1,32 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccStreamSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeStreamConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_stream.foo", "name", "test_stream"),
+					resource.TestCheckResourceAttr("snowflake_stream.foo", "append_only", "true"),
+					resource.TestCheckResourceAttr("snowflake_stream.foo", "mode", "APPEND_ONLY"),
+					resource.TestCheckResourceAttr("snowflake_stream.foo", "stale", "false"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeStreamConfig = `resource "snowflake_stream" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_stream"
+	on_table = "MASTER.SAMPLE_SCHEMA.SAMPLE_TABLE"
+	append_only = true
+}`
@@ -0,0 +1,727 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceTable() *schema.Resource {
+	return &schema.Resource{
+		Create:        createTable,
+		Read:          readTable,
+		Update:        updateTable,
+		Delete:        deleteTable,
+		CustomizeDiff: customizeTableDiff,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the database in which to create the table",
+			},
+
+			"schema": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the schema in which to create the table",
+			},
+
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the table. Changing it renames the table in place.",
+			},
+
+			"column": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Columns of the table, in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": &schema.Schema{
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressDataTypeDiff,
+						},
+						"nullable": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"default": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "SQL expression used as the default value of the column",
+						},
+						"identity": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start_num": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+										Default:  1,
+									},
+									"step_num": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+										Default:  1,
+									},
+								},
+							},
+						},
+						"comment": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"previous_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name the column had before, to rename it in place rather than drop it and add a new one",
+						},
+					},
+				},
+			},
+
+			"cluster_by": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Expressions making up the clustering key",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"change_tracking": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"data_retention_days": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of days Time Travel data is kept for the table",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// tableColumn is a column of a snowflake_table, as configured or as read back
+// from DESCRIBE TABLE.
+type tableColumn struct {
+	name     string
+	dataType string
+	nullable bool
+	def      string
+	identity bool
+	startNum int
+	stepNum  int
+	comment  string
+
+	// previousName is only known from the configuration, see readTable.
+	previousName string
+}
+
+func columnsFromList(list []interface{}) []tableColumn {
+	columns := make([]tableColumn, 0, len(list))
+	for _, v := range list {
+		m := v.(map[string]interface{})
+		column := tableColumn{
+			name:     m["name"].(string),
+			dataType: m["type"].(string),
+			nullable: m["nullable"].(bool),
+			def:      m["default"].(string),
+			comment:  m["comment"].(string),
+		}
+		if previousName, ok := m["previous_name"].(string); ok {
+			column.previousName = previousName
+		}
+		if identity := m["identity"].([]interface{}); len(identity) > 0 && identity[0] != nil {
+			im := identity[0].(map[string]interface{})
+			column.identity = true
+			column.startNum = im["start_num"].(int)
+			column.stepNum = im["step_num"].(int)
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func (c tableColumn) toMap() map[string]interface{} {
+	m := map[string]interface{}{
+		"name":          c.name,
+		"type":          c.dataType,
+		"nullable":      c.nullable,
+		"default":       c.def,
+		"comment":       c.comment,
+		"previous_name": c.previousName,
+	}
+	if c.identity {
+		m["identity"] = []interface{}{map[string]interface{}{
+			"start_num": c.startNum,
+			"step_num":  c.stepNum,
+		}}
+	}
+	return m
+}
+
+// definition returns the column definition used in CREATE TABLE and ADD COLUMN.
+func (c tableColumn) definition() string {
+	def := fmt.Sprintf("\"%s\" %s", c.name, c.dataType)
+	if c.identity {
+		def += fmt.Sprintf(" IDENTITY(%d, %d)", c.startNum, c.stepNum)
+	} else if c.def != "" {
+		def += fmt.Sprintf(" DEFAULT %s", c.def)
+	}
+	if !c.nullable {
+		def += " NOT NULL"
+	}
+	if c.comment != "" {
+		def += fmt.Sprintf(" COMMENT %s", quoteString(c.comment))
+	}
+	return def
+}
+
+func createTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	var definitions []string
+	for _, column := range columnsFromList(d.Get("column").([]interface{})) {
+		definitions = append(definitions, column.definition())
+	}
+
+	stmtSQL := fmt.Sprintf("CREATE TABLE %s (%s)", qualifiedName(database, schemaName, name), strings.Join(definitions, ", "))
+
+	if v, ok := d.GetOk("cluster_by"); ok {
+		stmtSQL += fmt.Sprintf(" CLUSTER BY (%s)", listToString(v.([]interface{})))
+	}
+
+	if v, ok := d.GetOk("data_retention_days"); ok {
+		stmtSQL += fmt.Sprintf(" DATA_RETENTION_TIME_IN_DAYS = %d", v.(int))
+	}
+
+	if d.Get("change_tracking").(bool) {
+		stmtSQL += " CHANGE_TRACKING = TRUE"
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(tableIDFromParams(database, schemaName, name))
+
+	return readTable(d, meta)
+}
+
+func readTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW TABLES LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		// SHOW TABLES LIKE is case-insensitive but table names are not.
+		if row["name"].String != name {
+			continue
+		}
+
+		retention, err := strconv.Atoi(row["retention_time"].String)
+		if err != nil {
+			return fmt.Errorf("Unexpected retention_time %q for table %s: %s", row["retention_time"].String, name, err)
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("comment", row["comment"].String)
+		d.Set("cluster_by", parseClusterBy(row["cluster_by"].String))
+		d.Set("change_tracking", row["change_tracking"].String == "ON")
+		d.Set("data_retention_days", retention)
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] table %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+		return nil
+	}
+
+	columns, err := describeTableColumns(meta, database, schemaName, name)
+	if err != nil {
+		return err
+	}
+
+	// DESCRIBE TABLE cannot tell what a column used to be called, so
+	// previous_name is carried over from the current state.
+	previousNames := make(map[string]string)
+	for _, column := range columnsFromList(d.Get("column").([]interface{})) {
+		previousNames[column.name] = column.previousName
+	}
+
+	list := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		column.previousName = previousNames[column.name]
+		list = append(list, column.toMap())
+	}
+	d.Set("column", list)
+
+	return nil
+}
+
+var identityDefaultRegexp = regexp.MustCompile(`^IDENTITY START (-?\d+) INCREMENT (-?\d+)`)
+
+func describeTableColumns(meta interface{}, database, schemaName, name string) ([]tableColumn, error) {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("DESCRIBE TABLE %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var columns []tableColumn
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if row["kind"].String != "COLUMN" {
+			continue
+		}
+
+		column := tableColumn{
+			name:     row["name"].String,
+			dataType: row["type"].String,
+			nullable: row["null?"].String == "Y",
+			comment:  row["comment"].String,
+		}
+
+		if match := identityDefaultRegexp.FindStringSubmatch(row["default"].String); match != nil {
+			column.identity = true
+			column.startNum, _ = strconv.Atoi(match[1])
+			column.stepNum, _ = strconv.Atoi(match[2])
+		} else {
+			column.def = row["default"].String
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+func updateTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	exec := func(stmtSQL string) error {
+		log.Println("Executing statement:", stmtSQL)
+		_, err := db.Exec(stmtSQL)
+		return err
+	}
+
+	if d.HasChange("name") {
+		newName := d.Get("name").(string)
+		stmtSQL := fmt.Sprintf("ALTER TABLE %s RENAME TO %s",
+			qualifiedName(database, schemaName, name),
+			qualifiedName(database, schemaName, newName))
+		if err := exec(stmtSQL); err != nil {
+			return err
+		}
+
+		name = newName
+		d.SetId(tableIDFromParams(database, schemaName, name))
+	}
+
+	table := qualifiedName(database, schemaName, name)
+
+	if d.HasChange("column") {
+		o, n := d.GetChange("column")
+		for _, stmtSQL := range columnChangeStatements(table, columnsFromList(o.([]interface{})), columnsFromList(n.([]interface{}))) {
+			if err := exec(stmtSQL); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("cluster_by") {
+		stmtSQL := fmt.Sprintf("ALTER TABLE %s DROP CLUSTERING KEY", table)
+		if clusterBy := d.Get("cluster_by").([]interface{}); len(clusterBy) > 0 {
+			stmtSQL = fmt.Sprintf("ALTER TABLE %s CLUSTER BY (%s)", table, listToString(clusterBy))
+		}
+		if err := exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("change_tracking") {
+		if err := exec(fmt.Sprintf("ALTER TABLE %s SET CHANGE_TRACKING = %t", table, d.Get("change_tracking").(bool))); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("data_retention_days") {
+		if err := exec(fmt.Sprintf("ALTER TABLE %s SET DATA_RETENTION_TIME_IN_DAYS = %d", table, d.Get("data_retention_days").(int))); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("comment") {
+		stmtSQL := fmt.Sprintf("ALTER TABLE %s UNSET COMMENT", table)
+		if comment := d.Get("comment").(string); comment != "" {
+			stmtSQL = fmt.Sprintf("ALTER TABLE %s SET COMMENT = %s", table, quoteString(comment))
+		}
+		if err := exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readTable(d, meta)
+}
+
+// columnChangeStatements returns the ALTER TABLE statements which turn the old
+// columns into the new ones. Columns are matched by name; a new column whose
+// previous_name is an old column which no longer exists is renamed rather than
+// dropped and added again, so that its data is kept.
+func columnChangeStatements(table string, oldColumns, newColumns []tableColumn) []string {
+	var statements []string
+
+	oldByName := make(map[string]tableColumn, len(oldColumns))
+	for _, c := range oldColumns {
+		oldByName[c.name] = c
+	}
+	newByName := make(map[string]tableColumn, len(newColumns))
+	for _, c := range newColumns {
+		newByName[c.name] = c
+	}
+
+	for _, newColumn := range newColumns {
+		if renamedColumn(newColumn, oldByName, newByName) {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN \"%s\" TO \"%s\"", table, newColumn.previousName, newColumn.name))
+			oldColumn := oldByName[newColumn.previousName]
+			delete(oldByName, newColumn.previousName)
+			oldColumn.name = newColumn.name
+			oldByName[newColumn.name] = oldColumn
+		}
+	}
+
+	var dropped []string
+	for _, c := range oldColumns {
+		if _, ok := oldByName[c.name]; !ok {
+			// Renamed above.
+			continue
+		}
+		if _, ok := newByName[c.name]; !ok {
+			dropped = append(dropped, fmt.Sprintf("\"%s\"", c.name))
+		}
+	}
+	if len(dropped) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, strings.Join(dropped, ", ")))
+	}
+
+	for _, newColumn := range newColumns {
+		oldColumn, ok := oldByName[newColumn.name]
+		if !ok {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, newColumn.definition()))
+			continue
+		}
+
+		column := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\"", table, newColumn.name)
+
+		if normalizeDataType(oldColumn.dataType) != normalizeDataType(newColumn.dataType) {
+			statements = append(statements, fmt.Sprintf("%s SET DATA TYPE %s", column, newColumn.dataType))
+		}
+		if oldColumn.nullable != newColumn.nullable {
+			if newColumn.nullable {
+				statements = append(statements, fmt.Sprintf("%s DROP NOT NULL", column))
+			} else {
+				statements = append(statements, fmt.Sprintf("%s SET NOT NULL", column))
+			}
+		}
+		if oldColumn.def != "" && newColumn.def == "" && !oldColumn.identity {
+			statements = append(statements, fmt.Sprintf("%s DROP DEFAULT", column))
+		}
+		if oldColumn.comment != newColumn.comment {
+			if newColumn.comment == "" {
+				statements = append(statements, fmt.Sprintf("%s UNSET COMMENT", column))
+			} else {
+				statements = append(statements, fmt.Sprintf("%s COMMENT %s", column, quoteString(newColumn.comment)))
+			}
+		}
+	}
+
+	return statements
+}
+
+// renamedColumn reports whether newColumn renames the old column named by its
+// previous_name. The old column must exist and no longer be configured under
+// its own name, and the new name must not be taken already.
+func renamedColumn(newColumn tableColumn, oldByName, newByName map[string]tableColumn) bool {
+	if newColumn.previousName == "" || newColumn.previousName == newColumn.name {
+		return false
+	}
+	if _, ok := oldByName[newColumn.previousName]; !ok {
+		return false
+	}
+	if _, ok := newByName[newColumn.previousName]; ok {
+		return false
+	}
+	_, ok := oldByName[newColumn.name]
+	return !ok
+}
+
+func deleteTable(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP TABLE %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeTableDiff rejects column changes which Snowflake cannot make in
+// place: setting a new default or identity on an existing column, and adding
+// or moving columns anywhere but at the end, as ADD COLUMN always appends.
+func customizeTableDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("column") {
+		return nil
+	}
+
+	o, n := d.GetChange("column")
+	oldColumns := columnsFromList(o.([]interface{}))
+	newColumns := columnsFromList(n.([]interface{}))
+
+	oldByName := make(map[string]tableColumn)
+	oldPosition := make(map[string]int)
+	for i, c := range oldColumns {
+		oldByName[c.name] = c
+		oldPosition[c.name] = i
+	}
+	newByName := make(map[string]tableColumn)
+	for _, c := range newColumns {
+		newByName[c.name] = c
+	}
+
+	lastPosition := -1
+	added := ""
+	for _, newColumn := range newColumns {
+		if newColumn.identity && newColumn.def != "" {
+			return fmt.Errorf("column %s cannot have both a default and an identity", newColumn.name)
+		}
+
+		name := newColumn.name
+		if renamedColumn(newColumn, oldByName, newByName) {
+			name = newColumn.previousName
+		}
+
+		oldColumn, ok := oldByName[name]
+		if !ok {
+			added = newColumn.name
+			continue
+		}
+		if added != "" {
+			return fmt.Errorf("column %s can only be added after the existing columns, as Snowflake adds new columns at the end of the table", added)
+		}
+		if oldPosition[name] < lastPosition {
+			return fmt.Errorf("column %s cannot be moved, as Snowflake cannot reorder the columns of a table", newColumn.name)
+		}
+		lastPosition = oldPosition[name]
+
+		if newColumn.def != "" && newColumn.def != oldColumn.def {
+			return fmt.Errorf("the default of existing column %s cannot be changed, add a new column instead", newColumn.name)
+		}
+		if newColumn.identity != oldColumn.identity || newColumn.startNum != oldColumn.startNum || newColumn.stepNum != oldColumn.stepNum {
+			return fmt.Errorf("the identity of existing column %s cannot be changed, add a new column instead", newColumn.name)
+		}
+	}
+
+	return nil
+}
+
+// dataTypeSynonyms maps data type names to the name Snowflake reports them as.
+var dataTypeSynonyms = map[string]string{
+	"BIGINT":                      "NUMBER",
+	"BYTEINT":                     "NUMBER",
+	"CHAR VARYING":                "VARCHAR",
+	"CHARACTER":                   "CHAR",
+	"DATETIME":                    "TIMESTAMP_NTZ",
+	"DECIMAL":                     "NUMBER",
+	"DOUBLE":                      "FLOAT",
+	"DOUBLE PRECISION":            "FLOAT",
+	"FLOAT4":                      "FLOAT",
+	"FLOAT8":                      "FLOAT",
+	"INT":                         "NUMBER",
+	"INTEGER":                     "NUMBER",
+	"NCHAR VARYING":               "VARCHAR",
+	"NUMERIC":                     "NUMBER",
+	"NVARCHAR":                    "VARCHAR",
+	"NVARCHAR2":                   "VARCHAR",
+	"REAL":                        "FLOAT",
+	"SMALLINT":                    "NUMBER",
+	"STRING":                      "VARCHAR",
+	"TEXT":                        "VARCHAR",
+	"TIMESTAMP":                   "TIMESTAMP_NTZ",
+	"TINYINT":                     "NUMBER",
+	"VARBINARY":                   "BINARY",
+	"TIMESTAMPLTZ":                "TIMESTAMP_LTZ",
+	"TIMESTAMPNTZ":                "TIMESTAMP_NTZ",
+	"TIMESTAMPTZ":                 "TIMESTAMP_TZ",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP_NTZ",
+}
+
+// dataTypeDefaults gives the parameters Snowflake fills in for data types
+// declared without them.
+var dataTypeDefaults = map[string]string{
+	"BINARY":        "(8388608)",
+	"CHAR":          "(1)",
+	"NUMBER":        "(38,0)",
+	"TIME":          "(9)",
+	"TIMESTAMP_LTZ": "(9)",
+	"TIMESTAMP_NTZ": "(9)",
+	"TIMESTAMP_TZ":  "(9)",
+	"VARCHAR":       "(16777216)",
+}
+
+var dataTypeRegexp = regexp.MustCompile(`^([A-Z_0-9 ]+?)\s*(\(.*\))?$`)
+
+// normalizeDataType returns the form in which Snowflake reports a data type,
+// e.g. NUMBER(38,0) for INT and VARCHAR(16777216) for STRING.
+func normalizeDataType(dataType string) string {
+	dataType = strings.ToUpper(strings.TrimSpace(dataType))
+	match := dataTypeRegexp.FindStringSubmatch(dataType)
+	if match == nil {
+		return dataType
+	}
+
+	name := match[1]
+	params := strings.Replace(match[2], " ", "", -1)
+
+	if synonym, ok := dataTypeSynonyms[name]; ok {
+		// Integer types are always NUMBER(38,0), whatever their declared size.
+		if synonym == "NUMBER" && name != "DECIMAL" && name != "NUMERIC" {
+			return "NUMBER(38,0)"
+		}
+		name = synonym
+	}
+
+	if name == "CHAR" {
+		name = "VARCHAR"
+		if params == "" {
+			params = "(1)"
+		}
+	}
+	if name == "NUMBER" && params != "" && !strings.Contains(params, ",") {
+		params = strings.TrimSuffix(params, ")") + ",0)"
+	}
+	if params == "" {
+		params = dataTypeDefaults[name]
+	}
+
+	return name + params
+}
+
+func suppressDataTypeDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeDataType(old) == normalizeDataType(new)
+}
+
+var clusterByRegexp = regexp.MustCompile(`^LINEAR\((.*)\)$`)
+
+// parseClusterBy turns the cluster_by column of SHOW TABLES, e.g.
+// LINEAR(a, b), into the list of expressions.
+func parseClusterBy(clusterBy string) []string {
+	match := clusterByRegexp.FindStringSubmatch(clusterBy)
+	if match == nil {
+		return nil
+	}
+
+	var expressions []string
+	for _, expression := range strings.Split(match[1], ",") {
+		expressions = append(expressions, strings.TrimSpace(expression))
+	}
+	return expressions
+}
+
+func paramsFromTableID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func tableIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
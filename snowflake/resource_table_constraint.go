@@ -0,0 +1,391 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// showKeysStatements maps constraint types to the SHOW command listing them.
+var showKeysStatements = map[string]string{
+	"PRIMARY KEY": "SHOW PRIMARY KEYS",
+	"UNIQUE":      "SHOW UNIQUE KEYS",
+	"FOREIGN KEY": "SHOW IMPORTED KEYS",
+}
+
+func resourceTableConstraint() *schema.Resource {
+	return &schema.Resource{
+		Create:        createTableConstraint,
+		Read:          readTableConstraint,
+		Update:        updateTableConstraint,
+		Delete:        deleteTableConstraint,
+		CustomizeDiff: customizeTableConstraintDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the constraint",
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PRIMARY KEY", "UNIQUE", "FOREIGN KEY"}, false),
+			},
+
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"table": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the table the constraint is defined on",
+			},
+
+			"columns": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"referenced_database": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Database of the referenced table. Defaults to the database of the table.",
+			},
+
+			"referenced_schema": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Schema of the referenced table. Defaults to the schema of the table.",
+			},
+
+			"referenced_table": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Table referenced by a FOREIGN KEY constraint",
+			},
+
+			"referenced_columns": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Columns referenced by a FOREIGN KEY constraint, in the order of columns",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"enforced": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the constraint is enforced. Snowflake only enforces NOT NULL, so this is informational.",
+			},
+
+			"deferrable": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"rely": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the optimizer may rely on the constraint, e.g. for join elimination",
+			},
+		},
+	}
+}
+
+func createTableConstraint(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	var (
+		name         = d.Get("name").(string)
+		databaseName = d.Get("database").(string)
+		schemaName   = d.Get("schema").(string)
+		table        = d.Get("table").(string)
+		constraint   = d.Get("type").(string)
+	)
+
+	stmtSQL := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT \"%s\" %s (%s)",
+		qualifiedName(databaseName, schemaName, table), name, constraint,
+		columnListToString(d.Get("columns").([]interface{})))
+
+	if constraint == "FOREIGN KEY" {
+		referencedDatabase, referencedSchema := referencedTableParams(d)
+		stmtSQL += fmt.Sprintf(" REFERENCES %s (%s)",
+			qualifiedName(referencedDatabase, referencedSchema, d.Get("referenced_table").(string)),
+			columnListToString(d.Get("referenced_columns").([]interface{})))
+	}
+
+	if !d.Get("enforced").(bool) {
+		stmtSQL += " NOT"
+	}
+	stmtSQL += " ENFORCED"
+
+	if !d.Get("deferrable").(bool) {
+		stmtSQL += " NOT"
+	}
+	stmtSQL += " DEFERRABLE"
+
+	stmtSQL += " " + relyKeyword(d.Get("rely").(bool))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId(tableConstraintIDFromParams(databaseName, schemaName, table, name))
+
+	return readTableConstraint(d, meta)
+}
+
+// keyColumn is a column of a constraint as listed by SHOW ... KEYS.
+type keyColumn struct {
+	sequence         int
+	column           string
+	referencedColumn string
+}
+
+func readTableConstraint(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	databaseName, schemaName, table, name, err := paramsFromTableConstraintID(d.Id())
+	if err != nil {
+		return err
+	}
+	constraint := d.Get("type").(string)
+
+	stmtSQL := fmt.Sprintf("%s IN TABLE %s", showKeysStatements[constraint], qualifiedName(databaseName, schemaName, table))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var (
+		columns []keyColumn
+		rely    bool
+	)
+
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if constraint == "FOREIGN KEY" {
+			if row["fk_name"].String != name {
+				continue
+			}
+
+			sequence, _ := strconv.Atoi(row["key_sequence"].String)
+			columns = append(columns, keyColumn{
+				sequence:         sequence,
+				column:           row["fk_column_name"].String,
+				referencedColumn: row["pk_column_name"].String,
+			})
+
+			d.Set("referenced_database", row["pk_database_name"].String)
+			d.Set("referenced_schema", row["pk_schema_name"].String)
+			d.Set("referenced_table", row["pk_table_name"].String)
+			d.Set("deferrable", row["deferrability"].String != "NOT DEFERRABLE")
+		} else {
+			if row["constraint_name"].String != name {
+				continue
+			}
+
+			sequence, _ := strconv.Atoi(row["key_sequence"].String)
+			columns = append(columns, keyColumn{
+				sequence: sequence,
+				column:   row["column_name"].String,
+			})
+		}
+
+		rely = strings.ToLower(row["rely"].String) == "true"
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		log.Printf("[WARN] constraint %s on table %s not found, removing from state", name, qualifiedName(databaseName, schemaName, table))
+		d.SetId("")
+		return nil
+	}
+
+	sort.Slice(columns, func(i, j int) bool { return columns[i].sequence < columns[j].sequence })
+
+	var columnNames, referencedColumnNames []string
+	for _, c := range columns {
+		columnNames = append(columnNames, c.column)
+		if constraint == "FOREIGN KEY" {
+			referencedColumnNames = append(referencedColumnNames, c.referencedColumn)
+		}
+	}
+
+	d.Set("name", name)
+	d.Set("database", databaseName)
+	d.Set("schema", schemaName)
+	d.Set("table", table)
+	d.Set("columns", columnNames)
+	d.Set("referenced_columns", referencedColumnNames)
+	d.Set("rely", rely)
+
+	return nil
+}
+
+// updateTableConstraint changes the flags which can be modified without
+// recreating the constraint.
+func updateTableConstraint(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	databaseName, schemaName, table, name, err := paramsFromTableConstraintID(d.Id())
+	if err != nil {
+		return err
+	}
+	constraint := fmt.Sprintf("ALTER TABLE %s MODIFY CONSTRAINT \"%s\"", qualifiedName(databaseName, schemaName, table), name)
+
+	var statements []string
+	if d.HasChange("enforced") {
+		if d.Get("enforced").(bool) {
+			statements = append(statements, constraint+" ENFORCED")
+		} else {
+			statements = append(statements, constraint+" NOT ENFORCED")
+		}
+	}
+	if d.HasChange("rely") {
+		statements = append(statements, constraint+" "+relyKeyword(d.Get("rely").(bool)))
+	}
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return readTableConstraint(d, meta)
+}
+
+func deleteTableConstraint(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	databaseName, schemaName, table, name, err := paramsFromTableConstraintID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT \"%s\"", qualifiedName(databaseName, schemaName, table), name)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeTableConstraintDiff checks that the referenced table and columns
+// are given for, and only for, FOREIGN KEY constraints.
+func customizeTableConstraintDiff(d *schema.ResourceDiff, meta interface{}) error {
+	constraint := d.Get("type").(string)
+	referencedTable := d.Get("referenced_table").(string)
+	referencedColumns := d.Get("referenced_columns").([]interface{})
+
+	if constraint != "FOREIGN KEY" {
+		if referencedTable != "" || len(referencedColumns) > 0 {
+			return fmt.Errorf("referenced_table and referenced_columns can only be set on FOREIGN KEY constraints")
+		}
+		return nil
+	}
+
+	if !d.NewValueKnown("referenced_table") || !d.NewValueKnown("referenced_columns") || !d.NewValueKnown("columns") {
+		return nil
+	}
+	if referencedTable == "" {
+		return fmt.Errorf("referenced_table must be set on FOREIGN KEY constraints")
+	}
+	if columns := d.Get("columns").([]interface{}); len(referencedColumns) != len(columns) {
+		return fmt.Errorf("referenced_columns must list as many columns as columns (%d), got %d", len(columns), len(referencedColumns))
+	}
+
+	return nil
+}
+
+// referencedTableParams returns the database and schema of the referenced
+// table, defaulting to those of the constrained table.
+func referencedTableParams(d *schema.ResourceData) (database, schema string) {
+	database = d.Get("referenced_database").(string)
+	if database == "" {
+		database = d.Get("database").(string)
+	}
+	schema = d.Get("referenced_schema").(string)
+	if schema == "" {
+		schema = d.Get("schema").(string)
+	}
+	return database, schema
+}
+
+// columnListToString quotes and joins a list of column names.
+func columnListToString(list []interface{}) string {
+	columns := make([]string, 0, len(list))
+	for _, v := range list {
+		columns = append(columns, fmt.Sprintf("\"%s\"", v.(string)))
+	}
+	return strings.Join(columns, ", ")
+}
+
+func relyKeyword(rely bool) string {
+	if rely {
+		return "RELY"
+	}
+	return "NORELY"
+}
+
+func paramsFromTableConstraintID(id string) (database, schema, table, name string, err error) {
+	params, err := paramsFromID(id, 4)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return params[0], params[1], params[2], params[3], nil
+}
+
+func tableConstraintIDFromParams(database, schema, table, name string) string {
+	return idFromParams(database, schema, table, name)
+}
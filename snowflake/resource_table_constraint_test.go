@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccTableConstraintSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeTableConstraintConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_table_constraint.foo", "type", "PRIMARY KEY"),
+					resource.TestCheckResourceAttr("snowflake_table_constraint.foo", "columns.#", "1"),
+					resource.TestCheckResourceAttr("snowflake_table_constraint.foo", "columns.0", "ID"),
+					resource.TestCheckResourceAttr("snowflake_table_constraint.foo", "rely", "true"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeTableConstraintConfig = `resource "snowflake_table_constraint" "foo" {
+	name = "test_table_pk"
+	type = "PRIMARY KEY"
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	table = "SAMPLE_TABLE"
+	columns = ["ID"]
+	rely = true
+}`
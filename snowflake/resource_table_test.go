@@ -0,0 +1,79 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccTableSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeTableConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_table.foo", "name", "test_table"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.#", "2"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.0.name", "ID"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.0.nullable", "false"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.1.comment", "the name"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "comment", "test table"),
+				),
+			},
+			{
+				Config: testSnowflakeTableRenamedColumnConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.#", "3"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.1.name", "FULL_NAME"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.1.previous_name", "NAME"),
+					resource.TestCheckResourceAttr("snowflake_table.foo", "column.2.name", "CREATED_AT"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeTableConfig = `resource "snowflake_table" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_table"
+	comment = "test table"
+
+	column {
+		name = "ID"
+		type = "NUMBER(38,0)"
+		nullable = false
+	}
+
+	column {
+		name = "NAME"
+		type = "VARCHAR"
+		comment = "the name"
+	}
+}`
+
+var testSnowflakeTableRenamedColumnConfig = `resource "snowflake_table" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_table"
+	comment = "test table"
+
+	column {
+		name = "ID"
+		type = "NUMBER(38,0)"
+		nullable = false
+	}
+
+	column {
+		name = "FULL_NAME"
+		previous_name = "NAME"
+		type = "VARCHAR"
+		comment = "the name"
+	}
+
+	column {
+		name = "CREATED_AT"
+		type = "TIMESTAMP_NTZ"
+	}
+}`
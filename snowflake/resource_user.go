@@ -1,18 +1,76 @@
 package snowflake
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
+// userTypes lists the values accepted by the TYPE property of a user.
+var userTypes = []string{"PERSON", "SERVICE", "LEGACY_SERVICE"}
+
+// Kinds of user-level parameter values, used to decide how a value is
+// validated and quoted.
+const (
+	userParamString     = "STRING"
+	userParamNumber     = "NUMBER"
+	userParamBoolean    = "BOOLEAN"
+	userParamIdentifier = "IDENTIFIER"
+)
+
+// userParameters lists the parameters which can be set on an individual user
+// with ALTER USER ... SET, along with the kind of value each one takes.
+var userParameters = map[string]string{
+	"ABORT_DETACHED_QUERY":                          userParamBoolean,
+	"AUTOCOMMIT":                                    userParamBoolean,
+	"BINARY_INPUT_FORMAT":                           userParamString,
+	"BINARY_OUTPUT_FORMAT":                          userParamString,
+	"CLIENT_PREFETCH_THREADS":                       userParamNumber,
+	"CLIENT_RESULT_CHUNK_SIZE":                      userParamNumber,
+	"CLIENT_SESSION_KEEP_ALIVE":                     userParamBoolean,
+	"CLIENT_SESSION_KEEP_ALIVE_HEARTBEAT_FREQUENCY": userParamNumber,
+	"DATE_INPUT_FORMAT":                             userParamString,
+	"DATE_OUTPUT_FORMAT":                            userParamString,
+	"ERROR_ON_NONDETERMINISTIC_MERGE":               userParamBoolean,
+	"ERROR_ON_NONDETERMINISTIC_UPDATE":              userParamBoolean,
+	"JSON_INDENT":                                   userParamNumber,
+	"LOCK_TIMEOUT":                                  userParamNumber,
+	"NETWORK_POLICY":                                userParamIdentifier,
+	"QUERY_TAG":                                     userParamString,
+	"QUOTED_IDENTIFIERS_IGNORE_CASE":                userParamBoolean,
+	"ROWS_PER_RESULTSET":                            userParamNumber,
+	"STATEMENT_QUEUED_TIMEOUT_IN_SECONDS":           userParamNumber,
+	"STATEMENT_TIMEOUT_IN_SECONDS":                  userParamNumber,
+	"STRICT_JSON_OUTPUT":                            userParamBoolean,
+	"TIMESTAMP_DAY_IS_ALWAYS_24H":                   userParamBoolean,
+	"TIMESTAMP_INPUT_FORMAT":                        userParamString,
+	"TIMESTAMP_LTZ_OUTPUT_FORMAT":                   userParamString,
+	"TIMESTAMP_NTZ_OUTPUT_FORMAT":                   userParamString,
+	"TIMESTAMP_OUTPUT_FORMAT":                       userParamString,
+	"TIMESTAMP_TYPE_MAPPING":                        userParamString,
+	"TIMESTAMP_TZ_OUTPUT_FORMAT":                    userParamString,
+	"TIMEZONE":                                      userParamString,
+	"TIME_INPUT_FORMAT":                             userParamString,
+	"TIME_OUTPUT_FORMAT":                            userParamString,
+	"TRANSACTION_DEFAULT_ISOLATION_LEVEL":           userParamString,
+	"TWO_DIGIT_CENTURY_START":                       userParamNumber,
+	"USE_CACHED_RESULT":                             userParamBoolean,
+	"WEEK_OF_YEAR_POLICY":                           userParamNumber,
+	"WEEK_START":                                    userParamNumber,
+}
+
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateUser,
-		Update: UpdateUser,
-		Read:   ReadUser,
-		Delete: DeleteUser,
+		Create:        CreateUser,
+		Update:        UpdateUser,
+		Read:          ReadUser,
+		Delete:        DeleteUser,
+		CustomizeDiff: customizeUserDiff,
 
 		Schema: map[string]*schema.Schema{
 			"user": &schema.Schema{
@@ -27,9 +85,25 @@ func resourceUser() *schema.Resource {
 				StateFunc: hashSum,
 			},
 			"rsa_public_key": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				DiffSuppressFunc: suppressRSAPublicKeyDiff,
+			},
+			"rsa_public_key_2": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				Description:      "Second RSA public key, used to rotate keys without downtime",
+				DiffSuppressFunc: suppressRSAPublicKeyDiff,
+			},
+			"rsa_public_key_fp": &schema.Schema{
 				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Computed: true,
+			},
+			"rsa_public_key_2_fp": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"password": &schema.Schema{
 				Type:          schema.TypeString,
@@ -42,6 +116,34 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"user_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PERSON",
+				Description:  "Type of the user: PERSON, SERVICE or LEGACY_SERVICE",
+				ValidateFunc: validation.StringInSlice(userTypes, false),
+			},
+			"first_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"last_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"mins_to_bypass_mfa": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of minutes during which the user may bypass MFA. Not read back from Snowflake.",
+			},
+			"parameters": &schema.Schema{
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				Description:      "Session parameters set at the level of the user, keyed by parameter name",
+				ValidateFunc:     validateUserParameters,
+				DiffSuppressFunc: suppressUserParameterDiff,
+			},
 		},
 	}
 }
@@ -66,10 +168,34 @@ func CreateUser(d *schema.ResourceData, meta interface{}) error {
 		stmtSQL = stmtSQL + fmt.Sprintf(" RSA_PUBLIC_KEY = \"%s\"", v.(string))
 	}
 
+	if v, ok := d.GetOk("rsa_public_key_2"); ok {
+		stmtSQL = stmtSQL + fmt.Sprintf(" RSA_PUBLIC_KEY_2 = \"%s\"", v.(string))
+	}
+
 	if v, ok := d.GetOk("default_role"); ok {
 		stmtSQL = stmtSQL + fmt.Sprintf(" DEFAULT_ROLE = \"%s\"", v.(string))
 	}
 
+	if v, ok := d.GetOk("user_type"); ok {
+		stmtSQL = stmtSQL + fmt.Sprintf(" TYPE = %s", v.(string))
+	}
+
+	if v, ok := d.GetOk("first_name"); ok {
+		stmtSQL = stmtSQL + fmt.Sprintf(" FIRST_NAME = %s", quoteString(v.(string)))
+	}
+
+	if v, ok := d.GetOk("last_name"); ok {
+		stmtSQL = stmtSQL + fmt.Sprintf(" LAST_NAME = %s", quoteString(v.(string)))
+	}
+
+	if v, ok := d.GetOk("mins_to_bypass_mfa"); ok {
+		stmtSQL = stmtSQL + fmt.Sprintf(" MINS_TO_BYPASS_MFA = %d", v.(int))
+	}
+
+	for _, param := range sortedKeys(d.Get("parameters").(map[string]interface{})) {
+		stmtSQL = stmtSQL + fmt.Sprintf(" %s = %s", param, userParameterValue(param, d.Get("parameters."+param).(string)))
+	}
+
 	log.Println("Executing statement:", stmtSQL)
 	_, err := db.Exec(stmtSQL)
 	if err != nil {
@@ -79,12 +205,18 @@ func CreateUser(d *schema.ResourceData, meta interface{}) error {
 	user := fmt.Sprintf("%s", d.Get("user").(string))
 	d.SetId(user)
 
-	return nil
+	return ReadUser(d, meta)
 }
 
 func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 	conf := meta.(*providerConfiguration)
 
+	// Properties cleared in the configuration are collected here and removed
+	// with UNSET, as setting them to an empty value is either rejected or not
+	// the same as the default. This is also how an old RSA key is dropped
+	// once every client has switched to the other one.
+	var unset []string
+
 	var newpw interface{}
 	if d.HasChange("plaintext_password") {
 		_, newpw = d.GetChange("plaintext_password")
@@ -93,6 +225,10 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		newpw = nil
 	}
+	if newpw != nil && newpw.(string) == "" {
+		unset = append(unset, "PASSWORD")
+		newpw = nil
+	}
 
 	var newdefrole interface{}
 	if d.HasChange("default_role") {
@@ -101,14 +237,72 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 		newdefrole = nil
 	}
 
+	var newUserType interface{}
+	if d.HasChange("user_type") {
+		_, newUserType = d.GetChange("user_type")
+	} else {
+		newUserType = nil
+	}
+
+	var newMinsToBypassMFA interface{}
+	if d.HasChange("mins_to_bypass_mfa") {
+		_, newMinsToBypassMFA = d.GetChange("mins_to_bypass_mfa")
+	} else {
+		newMinsToBypassMFA = nil
+	}
+
+	var newFirstName interface{}
+	if d.HasChange("first_name") {
+		_, newFirstName = d.GetChange("first_name")
+		if newFirstName.(string) == "" {
+			unset = append(unset, "FIRST_NAME")
+			newFirstName = nil
+		}
+	} else {
+		newFirstName = nil
+	}
+
+	var newLastName interface{}
+	if d.HasChange("last_name") {
+		_, newLastName = d.GetChange("last_name")
+		if newLastName.(string) == "" {
+			unset = append(unset, "LAST_NAME")
+			newLastName = nil
+		}
+	} else {
+		newLastName = nil
+	}
+
 	var newRSAPublicKey interface{}
 	if d.HasChange("rsa_public_key") {
 		_, newRSAPublicKey = d.GetChange("rsa_public_key")
+		if newRSAPublicKey.(string) == "" {
+			unset = append(unset, "RSA_PUBLIC_KEY")
+			newRSAPublicKey = nil
+		}
 	} else {
 		newRSAPublicKey = nil
 	}
 
-	if newpw != nil || newdefrole != nil || newRSAPublicKey != nil {
+	var newRSAPublicKey2 interface{}
+	if d.HasChange("rsa_public_key_2") {
+		_, newRSAPublicKey2 = d.GetChange("rsa_public_key_2")
+		if newRSAPublicKey2.(string) == "" {
+			unset = append(unset, "RSA_PUBLIC_KEY_2")
+			newRSAPublicKey2 = nil
+		}
+	} else {
+		newRSAPublicKey2 = nil
+	}
+
+	// Properties are unset before the others are set, as a user can only
+	// become a SERVICE user once its password and names are gone.
+	if err := unsetUserProperties(conf, d.Get("user").(string), unset); err != nil {
+		return err
+	}
+
+	if newpw != nil || newdefrole != nil || newRSAPublicKey != nil || newRSAPublicKey2 != nil ||
+		newUserType != nil || newMinsToBypassMFA != nil || newFirstName != nil || newLastName != nil {
 		stmtSQL := fmt.Sprintf("ALTER USER \"%s\" SET ", d.Get("user").(string))
 
 		if newpw != nil {
@@ -119,10 +313,30 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 			stmtSQL = stmtSQL + fmt.Sprintf(" RSA_PUBLIC_KEY = \"%s\"", newRSAPublicKey.(string))
 		}
 
+		if newRSAPublicKey2 != nil {
+			stmtSQL = stmtSQL + fmt.Sprintf(" RSA_PUBLIC_KEY_2 = \"%s\"", newRSAPublicKey2.(string))
+		}
+
 		if newdefrole != nil {
 			stmtSQL = stmtSQL + fmt.Sprintf(" DEFAULT_ROLE = \"%s\"", newdefrole.(string))
 		}
 
+		if newUserType != nil {
+			stmtSQL = stmtSQL + fmt.Sprintf(" TYPE = %s", newUserType.(string))
+		}
+
+		if newFirstName != nil {
+			stmtSQL = stmtSQL + fmt.Sprintf(" FIRST_NAME = %s", quoteString(newFirstName.(string)))
+		}
+
+		if newLastName != nil {
+			stmtSQL = stmtSQL + fmt.Sprintf(" LAST_NAME = %s", quoteString(newLastName.(string)))
+		}
+
+		if newMinsToBypassMFA != nil {
+			stmtSQL = stmtSQL + fmt.Sprintf(" MINS_TO_BYPASS_MFA = %d", newMinsToBypassMFA.(int))
+		}
+
 		log.Println("Executing query:", stmtSQL)
 		_, err := conf.DB.Exec(stmtSQL)
 		if err != nil {
@@ -130,7 +344,51 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return nil
+	if d.HasChange("parameters") {
+		o, n := d.GetChange("parameters")
+		oldParams := o.(map[string]interface{})
+		newParams := n.(map[string]interface{})
+
+		var set, unset []string
+		for _, param := range sortedKeys(newParams) {
+			if oldParams[param] != newParams[param] {
+				set = append(set, fmt.Sprintf("%s = %s", param, userParameterValue(param, newParams[param].(string))))
+			}
+		}
+		for _, param := range sortedKeys(oldParams) {
+			if _, ok := newParams[param]; !ok {
+				unset = append(unset, param)
+			}
+		}
+
+		if len(set) > 0 {
+			stmtSQL := fmt.Sprintf("ALTER USER \"%s\" SET %s", d.Get("user").(string), strings.Join(set, " "))
+
+			log.Println("Executing query:", stmtSQL)
+			_, err := conf.DB.Exec(stmtSQL)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := unsetUserProperties(conf, d.Get("user").(string), unset); err != nil {
+			return err
+		}
+	}
+
+	return ReadUser(d, meta)
+}
+
+func unsetUserProperties(conf *providerConfiguration, user string, unset []string) error {
+	if len(unset) == 0 {
+		return nil
+	}
+
+	stmtSQL := fmt.Sprintf("ALTER USER \"%s\" UNSET %s", user, strings.Join(unset, ", "))
+
+	log.Println("Executing query:", stmtSQL)
+	_, err := conf.DB.Exec(stmtSQL)
+	return err
 }
 
 func ReadUser(d *schema.ResourceData, meta interface{}) error {
@@ -146,10 +404,187 @@ func ReadUser(d *schema.ResourceData, meta interface{}) error {
 	}
 	defer rows.Close()
 
-	if !rows.Next() && rows.Err() == nil {
-		d.SetId("")
+	if !rows.Next() {
+		if rows.Err() == nil {
+			d.SetId("")
+		}
+		return rows.Err()
+	}
+
+	properties, err := describeUser(db, d.Get("user").(string))
+	if err != nil {
+		return err
+	}
+
+	d.Set("rsa_public_key", properties["RSA_PUBLIC_KEY"])
+	d.Set("rsa_public_key_2", properties["RSA_PUBLIC_KEY_2"])
+	d.Set("rsa_public_key_fp", properties["RSA_PUBLIC_KEY_FP"])
+	d.Set("rsa_public_key_2_fp", properties["RSA_PUBLIC_KEY_2_FP"])
+	d.Set("first_name", properties["FIRST_NAME"])
+	d.Set("last_name", properties["LAST_NAME"])
+
+	// Users created before TYPE existed report it as null, which Snowflake
+	// treats as PERSON.
+	if userType := properties["TYPE"]; userType != "" {
+		d.Set("user_type", userType)
+	} else {
+		d.Set("user_type", "PERSON")
+	}
+
+	parameters, err := showUserParameters(db, d.Get("user").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("parameters", parameters)
+
+	return nil
+}
+
+// customizeUserDiff rejects attributes which Snowflake does not allow for
+// service users. SERVICE users can only authenticate with key pairs or
+// OAuth, so they cannot have a password, and neither SERVICE nor
+// LEGACY_SERVICE users can have names or MFA settings.
+func customizeUserDiff(d *schema.ResourceDiff, meta interface{}) error {
+	userType := d.Get("user_type").(string)
+	if userType == "PERSON" {
+		return nil
+	}
+
+	disallowed := []string{"first_name", "last_name", "mins_to_bypass_mfa"}
+	if userType == "SERVICE" {
+		disallowed = append(disallowed, "plaintext_password", "password")
+	}
+
+	for _, attr := range disallowed {
+		if _, ok := d.GetOk(attr); ok {
+			return fmt.Errorf("%s cannot be set for users of type %s", attr, userType)
+		}
+	}
+
+	return nil
+}
+
+// describeUser returns the properties reported by DESCRIBE USER, keyed by
+// property name. Properties which are not set are returned as empty strings.
+func describeUser(db *sql.DB, user string) (map[string]string, error) {
+	stmtSQL := fmt.Sprintf("DESCRIBE USER \"%s\"", user)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	properties := make(map[string]string)
+	for rows.Next() {
+		var (
+			property     string
+			value        sql.NullString
+			defaultValue sql.NullString
+			description  sql.NullString
+		)
+		if err := rows.Scan(&property, &value, &defaultValue, &description); err != nil {
+			return nil, err
+		}
+
+		if value.Valid && value.String != "null" {
+			properties[property] = value.String
+		} else {
+			properties[property] = ""
+		}
+	}
+
+	return properties, rows.Err()
+}
+
+// showUserParameters returns the parameters which are set at the level of the
+// given user, ignoring values inherited from the account.
+func showUserParameters(db *sql.DB, user string) (map[string]string, error) {
+	stmtSQL := fmt.Sprintf("SHOW PARAMETERS IN USER \"%s\"", user)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parameters := make(map[string]string)
+	for rows.Next() {
+		var key, value, defaultValue, level, description, paramType sql.NullString
+		if err := rows.Scan(&key, &value, &defaultValue, &level, &description, &paramType); err != nil {
+			return nil, err
+		}
+
+		if level.String != "USER" {
+			continue
+		}
+		// Only report the parameters this resource knows how to manage, so
+		// that new parameters added by Snowflake don't show up as drift.
+		if _, ok := userParameters[key.String]; ok {
+			parameters[key.String] = value.String
+		}
+	}
+
+	return parameters, rows.Err()
+}
+
+func validateUserParameters(v interface{}, k string) (ws []string, errors []error) {
+	for param, value := range v.(map[string]interface{}) {
+		kind, ok := userParameters[param]
+		if !ok {
+			errors = append(errors, fmt.Errorf("%s: %q is not a known user-level parameter", k, param))
+			continue
+		}
+
+		switch kind {
+		case userParamNumber:
+			if _, err := strconv.Atoi(value.(string)); err != nil {
+				errors = append(errors, fmt.Errorf("%s: %s must be a number, got %q", k, param, value))
+			}
+		case userParamBoolean:
+			if _, err := strconv.ParseBool(value.(string)); err != nil {
+				errors = append(errors, fmt.Errorf("%s: %s must be true or false, got %q", k, param, value))
+			}
+		}
+	}
+	return
+}
+
+// suppressUserParameterDiff ignores differences in the case of boolean values,
+// which Snowflake always reports in lower case.
+func suppressUserParameterDiff(k, old, new string, d *schema.ResourceData) bool {
+	param := strings.TrimPrefix(k, "parameters.")
+	if userParameters[param] == userParamBoolean {
+		return strings.EqualFold(old, new)
+	}
+	return false
+}
+
+// suppressRSAPublicKeyDiff compares public keys without their PEM header and
+// footer and without white space, as DESCRIBE USER reports only the key body.
+func suppressRSAPublicKeyDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeRSAPublicKey(old) == normalizeRSAPublicKey(new)
+}
+
+func normalizeRSAPublicKey(key string) string {
+	key = strings.Replace(key, "-----BEGIN PUBLIC KEY-----", "", 1)
+	key = strings.Replace(key, "-----END PUBLIC KEY-----", "", 1)
+	return strings.Join(strings.Fields(key), "")
+}
+
+func userParameterValue(param, value string) string {
+	switch userParameters[param] {
+	case userParamString:
+		return quoteString(value)
+	case userParamIdentifier:
+		return fmt.Sprintf("\"%s\"", value)
+	default:
+		return value
 	}
-	return rows.Err()
 }
 
 func DeleteUser(d *schema.ResourceData, meta interface{}) error {
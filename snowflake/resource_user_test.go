@@ -16,6 +16,8 @@ func TestAccUserSnowflakeDatabase(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"snowflake_user", "name", "shoprunner_terraform"),
 					resource.TestCheckResourceAttr("snowflake_user", "user", "tf-test"),
+					resource.TestCheckResourceAttr("snowflake_user", "user_type", "PERSON"),
+					resource.TestCheckResourceAttr("snowflake_user", "parameters.TIMEZONE", "UTC"),
 				),
 			},
 		},
@@ -25,5 +27,92 @@ func TestAccUserSnowflakeDatabase(t *testing.T) {
 var testSnowflakeUserConfig = `
 resource "snowflake_user" "shoprunner_terraform" {
   user = "tf-test"
+
+  parameters = {
+    TIMEZONE = "UTC"
+  }
+}
+`
+
+func TestAccUserSnowflakeRSAPublicKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeUserRSAPublicKeysConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("snowflake_user.rsa_keys", "rsa_public_key_fp"),
+					resource.TestCheckResourceAttrSet("snowflake_user.rsa_keys", "rsa_public_key_2_fp"),
+				),
+			},
+			{
+				// The key with its PEM header must not show a diff against the
+				// bare key body reported by DESCRIBE USER.
+				Config:             testSnowflakeUserRSAPublicKeysConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+var testSnowflakeUserRSAPublicKeysConfig = `
+resource "snowflake_user" "rsa_keys" {
+  user = "tf-test-rsa"
+
+  rsa_public_key = <<KEY
+-----BEGIN PUBLIC KEY-----
+MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAq1SnNGAl6YmVEUIh9OOm
+SeP1O47WO5zP+DR1za1DrYmnwOeP6dQM/5LS2HNZFazeDsWAIPB2NhtBUeEgpiAC
+7iOU9J0Gk1+4hRFQB3gPuXmF4OqFkZu4OAz1hu6/9KU5jN6xZBDYfDmJ1dguhvMz
+oUNENWWADj9cs02us1/zkq4ODTQf0ZbgSwrnmwrryEF2mip7FRdS+xSDM1d9P8QQ
+E1qjah1gruWi6F3V1PVuy6G6ePkiBQnkfDNcuNYuk6TNa6Vw9rDo/wJj/ZRGUHQZ
+neWQyv3HXAwOhodAhJbO7RVYutMc/5JCFfeB6tJXpQ0xTgSGTMStX3RqkDFeWSok
+yQIDAQAB
+-----END PUBLIC KEY-----
+KEY
+
+  rsa_public_key_2 = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAmRJHjGcVfW20HxVwYj2Uwd3TV+yxFRcqXWEwB5ygkWiUNGSPqWQR7/mieCfxavJTVi8IpGewEmdekUDCzjKTysS6+jf++eH+3x8LOD59lCT6YyRFhrgYvGNxa3HzgD56s6PYjqQPPntwX0rZSeZdsri4B7oD0VHzhA41A++fT/ubP1fbbRxnJNvYRdP5YdtQZP78EOnBDPkeF59FBHhFz6q7HuxvNHHvF8xfX176Cu3Qpf3ulUq5eiAW6mLFjkfKJeVy42w3WqftoETAA4Jsj2cSRxg4GrwJ+oC5PT+IgJ+v9rnKcXyBzp4FsHsQzqpxlKSWEw1ZEVlir68/LHyO+wIDAQAB"
+}
+`
+
+func TestAccUserSnowflakeServiceType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeUserPersonConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_user.service", "user_type", "PERSON"),
+					resource.TestCheckResourceAttr("snowflake_user.service", "last_name", "O'Brien"),
+				),
+			},
+			{
+				// Becoming a SERVICE user requires the password and names to
+				// be unset first.
+				Config: testSnowflakeUserServiceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_user.service", "user_type", "SERVICE"),
+					resource.TestCheckResourceAttr("snowflake_user.service", "first_name", ""),
+					resource.TestCheckResourceAttr("snowflake_user.service", "last_name", ""),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeUserPersonConfig = `
+resource "snowflake_user" "service" {
+  user               = "tf-test-service"
+  plaintext_password = "Tf-test-Passw0rd"
+  first_name         = "Pat"
+  last_name          = "O'Brien"
+}
+`
+
+var testSnowflakeUserServiceConfig = `
+resource "snowflake_user" "service" {
+  user      = "tf-test-service"
+  user_type = "SERVICE"
 }
 `
@@ -0,0 +1,404 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceView() *schema.Resource {
+	return &schema.Resource{
+		Create: createView,
+		Read:   readView,
+		Update: updateView,
+		Delete: deleteView,
+
+		Schema: map[string]*schema.Schema{
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schema": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"statement": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The SELECT statement defining the view",
+				DiffSuppressFunc: suppressStatementDiff,
+			},
+
+			"is_secure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"or_replace": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to replace a view of the same name which already exists when creating it",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"column_comments": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Comments on columns of the view, keyed by column name",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func createView(d *schema.ResourceData, meta interface{}) error {
+	database := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	name := d.Get("name").(string)
+
+	if err := replaceView(d, meta, d.Get("or_replace").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(viewIDFromParams(database, schemaName, name))
+
+	if err := setViewColumnComments(meta, qualifiedName(database, schemaName, name), nil, d.Get("column_comments").(map[string]interface{})); err != nil {
+		return err
+	}
+
+	return readView(d, meta)
+}
+
+// replaceView runs CREATE VIEW with the configured statement. Existing views
+// are replaced keeping their grants.
+func replaceView(d *schema.ResourceData, meta interface{}, orReplace bool) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := "CREATE"
+	if orReplace {
+		stmtSQL += " OR REPLACE"
+	}
+	if d.Get("is_secure").(bool) {
+		stmtSQL += " SECURE"
+	}
+	stmtSQL += fmt.Sprintf(" VIEW %s", qualifiedName(d.Get("database").(string), d.Get("schema").(string), d.Get("name").(string)))
+
+	if orReplace {
+		stmtSQL += " COPY GRANTS"
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		stmtSQL += fmt.Sprintf(" COMMENT = %s", quoteString(v.(string)))
+	}
+
+	stmtSQL += fmt.Sprintf(" AS %s", d.Get("statement").(string))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	_, err := db.Exec(stmtSQL)
+	return err
+}
+
+func readView(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromViewID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("SHOW VIEWS LIKE '%s' IN SCHEMA %s", name, qualifiedName(database, schemaName))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if row["name"].String != name {
+			continue
+		}
+
+		d.Set("database", database)
+		d.Set("schema", schemaName)
+		d.Set("name", name)
+		d.Set("is_secure", strings.ToLower(row["is_secure"].String) == "true")
+		d.Set("comment", row["comment"].String)
+
+		// The text of secure views is only shown to their owner.
+		if statement := viewStatementFromText(row["text"].String); statement != "" {
+			d.Set("statement", statement)
+		}
+		found = true
+		break
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		log.Printf("[WARN] view %s not found, removing from state", qualifiedName(database, schemaName, name))
+		d.SetId("")
+		return nil
+	}
+
+	return readViewColumnComments(d, meta, qualifiedName(database, schemaName, name))
+}
+
+func readViewColumnComments(d *schema.ResourceData, meta interface{}, view string) error {
+	db := meta.(*providerConfiguration).DB
+
+	stmtSQL := fmt.Sprintf("DESCRIBE VIEW %s", view)
+
+	log.Println("Executing statement:", stmtSQL)
+
+	rows, err := db.Query(stmtSQL)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	comments := make(map[string]interface{})
+	for rows.Next() {
+		row, err := scanRowToMap(rows)
+		if err != nil {
+			return err
+		}
+
+		if comment := row["comment"].String; comment != "" {
+			comments[row["name"].String] = comment
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("column_comments", comments)
+	return nil
+}
+
+func updateView(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromViewID(d.Id())
+	if err != nil {
+		return err
+	}
+	view := qualifiedName(database, schemaName, name)
+
+	o, n := d.GetChange("column_comments")
+	oldComments := o.(map[string]interface{})
+
+	if d.HasChange("statement") {
+		if err := replaceView(d, meta, true); err != nil {
+			return err
+		}
+
+		// Replacing the view drops the column comments, so all of them have
+		// to be set again.
+		oldComments = nil
+	} else {
+		var statements []string
+
+		if d.HasChange("is_secure") {
+			if d.Get("is_secure").(bool) {
+				statements = append(statements, fmt.Sprintf("ALTER VIEW %s SET SECURE", view))
+			} else {
+				statements = append(statements, fmt.Sprintf("ALTER VIEW %s UNSET SECURE", view))
+			}
+		}
+
+		if d.HasChange("comment") {
+			if comment := d.Get("comment").(string); comment == "" {
+				statements = append(statements, fmt.Sprintf("ALTER VIEW %s UNSET COMMENT", view))
+			} else {
+				statements = append(statements, fmt.Sprintf("ALTER VIEW %s SET COMMENT = %s", view, quoteString(comment)))
+			}
+		}
+
+		for _, stmtSQL := range statements {
+			log.Println("Executing statement:", stmtSQL)
+			if _, err := db.Exec(stmtSQL); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := setViewColumnComments(meta, view, oldComments, n.(map[string]interface{})); err != nil {
+		return err
+	}
+
+	return readView(d, meta)
+}
+
+// setViewColumnComments sets the column comments which differ from the old
+// ones and unsets those which were removed.
+func setViewColumnComments(meta interface{}, view string, oldComments, newComments map[string]interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	var statements []string
+	for _, column := range sortedKeys(oldComments) {
+		if _, ok := newComments[column]; !ok {
+			statements = append(statements, fmt.Sprintf("ALTER VIEW %s ALTER COLUMN \"%s\" UNSET COMMENT", view, column))
+		}
+	}
+	for _, column := range sortedKeys(newComments) {
+		if comment := newComments[column]; oldComments[column] != comment {
+			statements = append(statements, fmt.Sprintf("ALTER VIEW %s ALTER COLUMN \"%s\" COMMENT %s", view, column, quoteString(comment.(string))))
+		}
+	}
+
+	for _, stmtSQL := range statements {
+		log.Println("Executing statement:", stmtSQL)
+		if _, err := db.Exec(stmtSQL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteView(d *schema.ResourceData, meta interface{}) error {
+	db := meta.(*providerConfiguration).DB
+
+	database, schemaName, name, err := paramsFromViewID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := fmt.Sprintf("DROP VIEW %s", qualifiedName(database, schemaName, name))
+
+	log.Println("Executing statement:", stmtSQL)
+
+	if _, err := db.Exec(stmtSQL); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+var viewTextRegexp = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:OR\s+REPLACE\s+)?(?:SECURE\s+)?(?:RECURSIVE\s+)?(?:MATERIALIZED\s+)?VIEW\s+`)
+
+// viewStatementFromText extracts the query from the CREATE statement shown in
+// the text column of SHOW VIEWS.
+func viewStatementFromText(text string) string {
+	loc := viewTextRegexp.FindStringIndex(text)
+	if loc == nil {
+		return ""
+	}
+	return statementAfterAS(text[loc[1]:])
+}
+
+// statementAfterAS returns what follows the first AS keyword of a CREATE
+// statement. Quoted strings and identifiers are skipped, so that a comment
+// such as 'counts as of today' is not taken for the keyword.
+func statementAfterAS(text string) string {
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\'' || c == '"':
+			i = quotedEnd(text, i) - 1
+		case isSpace(c) && i+3 < len(text) && strings.EqualFold(text[i+1:i+3], "AS") && isSpace(text[i+3]):
+			return strings.TrimSpace(text[i+4:])
+		}
+	}
+	return ""
+}
+
+// quotedEnd returns the index just after the quoted string or identifier
+// starting at text[start]. Backslash escapes are honoured in strings, and a
+// doubled quote simply starts a new quoted section.
+func quotedEnd(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quote == '\'' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(text)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// normalizeStatement collapses whitespace, drops a trailing semicolon and
+// upper-cases a SQL statement so that cosmetic differences are ignored.
+// Quoted strings and identifiers are kept as they are, as case and spacing
+// matter inside them.
+func normalizeStatement(statement string) string {
+	statement = strings.TrimSpace(statement)
+	statement = strings.TrimSpace(strings.TrimSuffix(statement, ";"))
+
+	var normalized strings.Builder
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := quotedEnd(statement, i)
+			normalized.WriteString(statement[i:end])
+			i = end - 1
+		case isSpace(c):
+			for i+1 < len(statement) && isSpace(statement[i+1]) {
+				i++
+			}
+			normalized.WriteByte(' ')
+		case c >= 'a' && c <= 'z':
+			normalized.WriteByte(c - 'a' + 'A')
+		default:
+			normalized.WriteByte(c)
+		}
+	}
+	return normalized.String()
+}
+
+func suppressStatementDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeStatement(old) == normalizeStatement(new)
+}
+
+func paramsFromViewID(id string) (database, schema, name string, err error) {
+	params, err := paramsFromID(id, 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return params[0], params[1], params[2], nil
+}
+
+func viewIDFromParams(database, schema, name string) string {
+	return idFromParams(database, schema, name)
+}
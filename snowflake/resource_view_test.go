@@ -0,0 +1,36 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccViewSnowflake(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testSnowflakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testSnowflakeViewConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("snowflake_view.foo", "name", "test_view"),
+					resource.TestCheckResourceAttr("snowflake_view.foo", "is_secure", "true"),
+					resource.TestCheckResourceAttr("snowflake_view.foo", "comment", "test view"),
+					resource.TestCheckResourceAttr("snowflake_view.foo", "column_comments.ID", "the id"),
+				),
+			},
+		},
+	})
+}
+
+var testSnowflakeViewConfig = `resource "snowflake_view" "foo" {
+	database = "MASTER"
+	schema = "SAMPLE_SCHEMA"
+	name = "test_view"
+	statement = "SELECT id FROM SAMPLE_TABLE"
+	is_secure = true
+	comment = "test view"
+	column_comments = {
+		ID = "the id"
+	}
+}`
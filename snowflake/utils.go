@@ -2,7 +2,9 @@ package snowflake
 
 import (
 	"crypto/sha256"
+	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -12,6 +14,24 @@ func hashSum(contents interface{}) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(contents.(string))))
 }
 
+// qualifiedName quotes each part of an object name and joins them with dots,
+// e.g. "db"."schema"."table", so statements never depend on the database or
+// schema in use by the session.
+func qualifiedName(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = fmt.Sprintf("\"%s\"", part)
+	}
+	return strings.Join(quoted, ".")
+}
+
+var stringQuoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// quoteString returns a single-quoted SQL string literal.
+func quoteString(in string) string {
+	return fmt.Sprintf("'%s'", stringQuoteReplacer.Replace(in))
+}
+
 func privilegesSetToString(priviligesSet *schema.Set) string {
 	if priviligesSet.Contains("ALL") {
 		return "ALL"
@@ -24,3 +44,145 @@ func privilegesSetToString(priviligesSet *schema.Set) string {
 
 	return strings.Join(privilegesList, ",")
 }
+
+// setToString joins the elements of a set of strings with commas, in lexical
+// order.
+func setToString(set *schema.Set) string {
+	var list []string
+	for _, v := range set.List() {
+		list = append(list, v.(string))
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+// listToString joins the elements of a list of strings with commas, keeping
+// their order.
+func listToString(list []interface{}) string {
+	elements := make([]string, 0, len(list))
+	for _, v := range list {
+		elements = append(elements, v.(string))
+	}
+	return strings.Join(elements, ", ")
+}
+
+// sortedKeys returns the keys of a map in lexical order, so that generated
+// statements are stable between runs.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// grantUpdateStatements returns the REVOKE and GRANT statements needed to take
+// role from the privileges and grant option recorded in state to the
+// configured ones on the given grant target, without touching privileges which
+// stay granted.
+func grantUpdateStatements(d *schema.ResourceData, on, role string) []string {
+	o, n := d.GetChange("privileges")
+	og, ng := d.GetChange("grant_option")
+
+	return privilegeChangeStatements(o.(*schema.Set), n.(*schema.Set), og.(bool), ng.(bool), on, fmt.Sprintf("ROLE \"%s\"", role))
+}
+
+// privilegeChangeStatements returns the statements moving grantee, such as
+// ROLE "name" or SHARE "name", from the old privileges and grant option to the
+// new ones. Revokes come first so that replacing a set containing ALL never
+// revokes a privilege which was just granted.
+func privilegeChangeStatements(oldPrivileges, newPrivileges *schema.Set, oldGrantOption, newGrantOption bool, on, grantee string) []string {
+	var statements []string
+
+	if revoked := oldPrivileges.Difference(newPrivileges); revoked.Len() > 0 {
+		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s",
+			privilegesSetToString(revoked), on, grantee))
+	}
+
+	kept := oldPrivileges.Intersection(newPrivileges)
+	if oldGrantOption && !newGrantOption && kept.Len() > 0 {
+		statements = append(statements, fmt.Sprintf("REVOKE GRANT OPTION FOR %s ON %s FROM %s",
+			privilegesSetToString(kept), on, grantee))
+	}
+
+	granted := newPrivileges.Difference(oldPrivileges)
+	if newGrantOption && !oldGrantOption {
+		granted = newPrivileges
+	}
+	if granted.Len() > 0 {
+		stmtSQL := fmt.Sprintf("GRANT %s ON %s TO %s", privilegesSetToString(granted), on, grantee)
+		if newGrantOption {
+			stmtSQL += " WITH GRANT OPTION"
+		}
+		statements = append(statements, stmtSQL)
+	}
+
+	return statements
+}
+
+// revokeStatement returns the statement used to revoke a grant on destroy. Only
+// the privileges recorded in state are revoked, so that grants made by others
+// on the same object and role survive, unless revoke_all_on_destroy is set.
+func revokeStatement(d *schema.ResourceData, on, role string) string {
+	privileges := "ALL PRIVILEGES"
+	if !d.Get("revoke_all_on_destroy").(bool) {
+		privileges = privilegesSetToString(d.Get("privileges").(*schema.Set))
+	}
+
+	return fmt.Sprintf("REVOKE %s ON %s FROM ROLE \"%s\"", privileges, on, role)
+}
+
+// suppressCaseDiff ignores differences in case, for keywords such as object
+// types which Snowflake accepts in either case, and for unquoted names which
+// Snowflake reports upper-cased.
+func suppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// idSeparator joins the parts of resource IDs. Quoted names may contain "-",
+// so it is not used for new ID formats.
+const idSeparator = "|"
+
+func idFromParams(params ...string) string {
+	return strings.Join(params, idSeparator)
+}
+
+// paramsFromID splits a resource ID into its n parts. IDs joined with "-" by
+// earlier versions are still accepted when they split into exactly n parts.
+func paramsFromID(id string, n int) ([]string, error) {
+	params := strings.Split(id, idSeparator)
+	if len(params) != n {
+		params = strings.Split(id, "-")
+	}
+	if len(params) != n {
+		return nil, fmt.Errorf("invalid ID %q, expected %d parts separated by %q", id, n, idSeparator)
+	}
+	return params, nil
+}
+
+// scanRowToMap scans the current row into a map keyed by lower-case column
+// name. The output of SHOW commands gains columns between Snowflake releases,
+// so reading them by name is safer than scanning a fixed number of columns.
+func scanRowToMap(rows *sql.Rows) (map[string]sql.NullString, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]sql.NullString, len(columns))
+	for i, column := range columns {
+		row[strings.ToLower(column)] = values[i]
+	}
+	return row, nil
+}